@@ -0,0 +1,91 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Checker periodically pings resource in background and stores its health status.
+type Checker struct {
+	ping     func(ctx context.Context) error
+	interval time.Duration
+	timeout  time.Duration
+	callback func(healthy bool)
+	healthy  atomic.Bool
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewChecker creates *Checker, which considers resource healthy on start. Provided callback, if not nil,
+// is called every time health status changes. Timeout limits every single ping; if zero, interval is used.
+func NewChecker(
+	ping func(ctx context.Context) error,
+	interval time.Duration,
+	timeout time.Duration,
+	callback func(healthy bool),
+) *Checker {
+	if timeout <= 0 {
+		timeout = interval
+	}
+
+	checker := &Checker{
+		ping:     ping,
+		interval: interval,
+		timeout:  timeout,
+		callback: callback,
+		stop:     make(chan struct{}),
+	}
+
+	checker.healthy.Store(true)
+
+	return checker
+}
+
+// Run starts health checks in background.
+func (c *Checker) Run() {
+	c.wg.Add(1)
+
+	go func() {
+		defer c.wg.Done()
+
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-c.stop:
+				return
+			case <-ticker.C:
+				c.check()
+			}
+		}
+	}()
+}
+
+// Healthy returns result of the last health check.
+func (c *Checker) Healthy() bool {
+	return c.healthy.Load()
+}
+
+// Stop stops health checks and waits for background goroutine to finish. Safe to call several times.
+func (c *Checker) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+
+	c.wg.Wait()
+}
+
+// check pings resource and updates health status.
+func (c *Checker) check() {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	healthy := c.ping(ctx) == nil
+	if previous := c.healthy.Swap(healthy); previous != healthy && c.callback != nil {
+		c.callback(healthy)
+	}
+}
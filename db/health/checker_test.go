@@ -0,0 +1,90 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DKhorkov/libs/db/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker(t *testing.T) {
+	t.Parallel()
+
+	t.Run("checker is healthy on start", func(t *testing.T) {
+		t.Parallel()
+
+		checker := health.NewChecker(
+			func(_ context.Context) error { return nil },
+			time.Hour,
+			0,
+			nil,
+		)
+
+		assert.True(t, checker.Healthy())
+	})
+
+	t.Run("checker reports status changes via callback", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			available atomic.Bool
+			statuses  = make(chan bool, 10)
+		)
+
+		checker := health.NewChecker(
+			func(_ context.Context) error {
+				if !available.Load() {
+					return errors.New("ping error")
+				}
+
+				return nil
+			},
+			time.Millisecond,
+			time.Millisecond,
+			func(healthy bool) {
+				statuses <- healthy
+			},
+		)
+
+		checker.Run()
+		defer checker.Stop()
+
+		select {
+		case healthy := <-statuses:
+			require.False(t, healthy)
+			require.False(t, checker.Healthy())
+		case <-time.After(time.Second):
+			t.Fatal("callback was not called for unhealthy status")
+		}
+
+		available.Store(true)
+
+		select {
+		case healthy := <-statuses:
+			require.True(t, healthy)
+			require.True(t, checker.Healthy())
+		case <-time.After(time.Second):
+			t.Fatal("callback was not called for healthy status")
+		}
+	})
+
+	t.Run("checker can be stopped several times", func(t *testing.T) {
+		t.Parallel()
+
+		checker := health.NewChecker(
+			func(_ context.Context) error { return nil },
+			time.Millisecond,
+			0,
+			nil,
+		)
+
+		checker.Run()
+		checker.Stop()
+		checker.Stop()
+	})
+}
@@ -0,0 +1,2 @@
+// Package health provides tools for waiting for database startup and for periodic database health checks.
+package health
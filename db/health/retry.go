@@ -0,0 +1,63 @@
+package health
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	defaultInitialBackoff = 100 * time.Millisecond
+	defaultMaxBackoff     = 5 * time.Second
+	backoffMultiplier     = 2
+)
+
+// RetryConfig configures retries of operation with exponential backoff.
+type RetryConfig struct {
+	// Deadline is a total time for all attempts. Zero value means, that operation is executed only once.
+	Deadline time.Duration
+
+	// InitialBackoff is a delay before second attempt. Each next delay is doubled.
+	InitialBackoff time.Duration
+
+	// MaxBackoff limits delay between attempts.
+	MaxBackoff time.Duration
+}
+
+// Retry executes operation until it succeeds or RetryConfig.Deadline is exceeded.
+// Returns the last operation error, if operation did not succeed.
+func Retry(ctx context.Context, config RetryConfig, operation func(ctx context.Context) error) error {
+	if config.Deadline <= 0 {
+		return operation(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, config.Deadline)
+	defer cancel()
+
+	backoff := config.InitialBackoff
+	if backoff <= 0 {
+		backoff = defaultInitialBackoff
+	}
+
+	maxBackoff := config.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+
+	for {
+		err := operation(ctx)
+		if err == nil {
+			return nil
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return err
+		case <-timer.C:
+		}
+
+		backoff = min(backoff*backoffMultiplier, maxBackoff)
+	}
+}
@@ -0,0 +1,78 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DKhorkov/libs/db/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("operation is executed once without deadline", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("operation error")
+		attempts := 0
+		err := health.Retry(
+			context.Background(),
+			health.RetryConfig{},
+			func(_ context.Context) error {
+				attempts++
+
+				return expectedErr
+			},
+		)
+
+		require.ErrorIs(t, err, expectedErr)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("operation succeeds after several attempts", func(t *testing.T) {
+		t.Parallel()
+
+		attempts := 0
+		err := health.Retry(
+			context.Background(),
+			health.RetryConfig{
+				Deadline:       time.Second,
+				InitialBackoff: time.Millisecond,
+				MaxBackoff:     2 * time.Millisecond,
+			},
+			func(_ context.Context) error {
+				attempts++
+				if attempts < 3 {
+					return errors.New("not ready yet")
+				}
+
+				return nil
+			},
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("last error is returned after deadline", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("operation error")
+		err := health.Retry(
+			context.Background(),
+			health.RetryConfig{
+				Deadline:       20 * time.Millisecond,
+				InitialBackoff: time.Millisecond,
+			},
+			func(_ context.Context) error {
+				return expectedErr
+			},
+		)
+
+		require.ErrorIs(t, err, expectedErr)
+	})
+}
@@ -0,0 +1,323 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Change event operation types.
+const (
+	OperationTypeInsert       = "insert"
+	OperationTypeUpdate       = "update"
+	OperationTypeReplace      = "replace"
+	OperationTypeDelete       = "delete"
+	OperationTypeDrop         = "drop"
+	OperationTypeRename       = "rename"
+	OperationTypeDropDatabase = "dropDatabase"
+	OperationTypeInvalidate   = "invalidate"
+)
+
+// Error codes, which mean, that change stream can not be resumed from saved token.
+const (
+	changeStreamFatalErrorCode       = 280
+	changeStreamHistoryLostErrorCode = 286
+)
+
+// ChangeEventNamespace represents database and collection, where change occurred.
+type ChangeEventNamespace struct {
+	Database   string `bson:"db"`
+	Collection string `bson:"coll"`
+}
+
+// UpdateDescription represents fields, which were changed by update operation.
+type UpdateDescription struct {
+	UpdatedFields bson.Raw `bson:"updatedFields"`
+	RemovedFields []string `bson:"removedFields"`
+}
+
+// ChangeEvent represents single change stream event. Raw contains the whole event document
+// for access to fields, which are not decoded to ChangeEvent, or fields, changed by pipeline.
+type ChangeEvent struct {
+	ID                bson.Raw             `bson:"_id"`
+	OperationType     string               `bson:"operationType"`
+	Namespace         ChangeEventNamespace `bson:"ns"`
+	DocumentKey       bson.Raw             `bson:"documentKey,omitempty"`
+	FullDocument      bson.Raw             `bson:"fullDocument,omitempty"`
+	UpdateDescription *UpdateDescription   `bson:"updateDescription,omitempty"`
+	ClusterTime       primitive.Timestamp  `bson:"clusterTime"`
+	Raw               bson.Raw             `bson:"-"`
+}
+
+// changeStreamState represents lifecycle state of change stream consumer.
+type changeStreamState int
+
+const (
+	changeStreamStateCreated changeStreamState = iota
+	changeStreamStateRunning
+	changeStreamStateStopped
+)
+
+// changeEventEnvelope links change event with its sequence number for resumeTokenTracker.
+type changeEventEnvelope struct {
+	sequence uint64
+	event    *ChangeEvent
+}
+
+// CommonChangeStreamConsumer watches changes of collection, database or whole deployment and processes
+// change events in goroutines pool. Resume token of processed events is saved to ResumeTokenStore
+// for continuing processing after restart.
+type CommonChangeStreamConsumer struct {
+	watcher          Watcher
+	name             string
+	eventChannel     chan changeEventEnvelope
+	tracker          *resumeTokenTracker
+	options          *changeStreamOptions
+	resumeToken      bson.Raw
+	savedVersion     uint64
+	cancel           context.CancelFunc
+	state            changeStreamState
+	mu               sync.Mutex
+	watcherWg        *sync.WaitGroup
+	workersWg        *sync.WaitGroup
+	tokenSaverDoneCh chan struct{}
+}
+
+// NewChangeStreamConsumer creates *CommonChangeStreamConsumer for provided Watcher, which can be
+// *mongo.Collection, *mongo.Database or *mongo.Client. Name is used as a key for resume token in ResumeTokenStore,
+// so it should be unique for each consumer.
+func NewChangeStreamConsumer(
+	watcher Watcher,
+	name string,
+	opts ...ChangeStreamOption,
+) (*CommonChangeStreamConsumer, error) {
+	if watcher == nil {
+		return nil, &NilCollectionError{Message: "Mongo change stream error. Nothing to watch"}
+	}
+
+	options := newChangeStreamOptions()
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, err
+		}
+	}
+
+	return &CommonChangeStreamConsumer{
+		watcher:          watcher,
+		name:             name,
+		eventChannel:     make(chan changeEventEnvelope, options.eventChannelBufferSize),
+		tracker:          newResumeTokenTracker(),
+		options:          options,
+		watcherWg:        new(sync.WaitGroup),
+		workersWg:        new(sync.WaitGroup),
+		tokenSaverDoneCh: make(chan struct{}),
+	}, nil
+}
+
+// Run loads saved resume token and starts goroutines for watching and processing change events.
+func (c *CommonChangeStreamConsumer) Run() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.state != changeStreamStateCreated {
+		return &ChangeStreamAlreadyRunningError{}
+	}
+
+	resumeToken, err := c.options.resumeTokenStore.Load(context.Background(), c.name)
+	if err != nil {
+		return err
+	}
+
+	c.resumeToken = resumeToken
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	// Events, which were already received, are processed during Stop, so handlers should not get canceled context:
+	handlerCtx := context.WithoutCancel(ctx)
+
+	c.workersWg.Add(c.options.goroutinesPoolSize)
+
+	for range c.options.goroutinesPoolSize {
+		go func() {
+			defer c.workersWg.Done()
+
+			for envelope := range c.eventChannel {
+				c.options.eventHandler(handlerCtx, envelope.event)
+				c.tracker.complete(envelope.sequence, envelope.event.ID)
+			}
+		}()
+	}
+
+	c.watcherWg.Add(1)
+
+	go func() {
+		defer c.watcherWg.Done()
+		defer close(c.eventChannel)
+
+		c.watch(ctx)
+	}()
+
+	go func() {
+		defer close(c.tokenSaverDoneCh)
+
+		c.saveResumeTokens(ctx)
+	}()
+
+	c.state = changeStreamStateRunning
+
+	return nil
+}
+
+// Stop stops watching changes, waits for already received events to be processed and saves
+// the last processed resume token. Stop is safe to call concurrently with Run and other Stop calls.
+func (c *CommonChangeStreamConsumer) Stop() error {
+	c.mu.Lock()
+	if c.state != changeStreamStateRunning {
+		c.mu.Unlock()
+
+		return &ChangeStreamAlreadyStoppedError{}
+	}
+
+	c.state = changeStreamStateStopped
+	c.mu.Unlock()
+
+	c.cancel()
+	c.watcherWg.Wait()
+	c.workersWg.Wait()
+	<-c.tokenSaverDoneCh
+
+	return c.saveResumeToken(context.Background())
+}
+
+// watch opens change stream and reopens it with backoff after errors and invalidate events until ctx is done.
+func (c *CommonChangeStreamConsumer) watch(ctx context.Context) {
+	backoff := c.options.reconnectBackoff
+
+	for ctx.Err() == nil {
+		err := c.consume(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err == nil {
+			backoff = c.options.reconnectBackoff
+
+			continue
+		}
+
+		c.options.errorHandler(err)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return
+		case <-timer.C:
+		}
+
+		backoff = min(backoff*2, c.options.maxReconnectBackoff)
+	}
+}
+
+// consume opens change stream and dispatches its events to processing goroutines. Returns nil, when stream
+// was closed due to invalidate event, and error, if stream should be reopened after backoff.
+func (c *CommonChangeStreamConsumer) consume(ctx context.Context) error {
+	streamOpts := c.options.toMongoOptions()
+	if c.resumeToken != nil {
+		// StartAfter is used instead of ResumeAfter, because it allows to continue after invalidate event:
+		streamOpts.SetStartAfter(c.resumeToken)
+	}
+
+	stream, err := c.watcher.Watch(ctx, c.options.pipeline, streamOpts)
+	if err != nil {
+		if isChangeStreamUnresumableError(err) {
+			// Saved token is outside of oplog, so processing starts from current moment:
+			c.resumeToken = nil
+		}
+
+		return err
+	}
+
+	defer func() {
+		_ = stream.Close(context.WithoutCancel(ctx))
+	}()
+
+	for stream.Next(ctx) {
+		event := &ChangeEvent{}
+		if err = stream.Decode(event); err != nil {
+			c.options.errorHandler(err)
+
+			continue
+		}
+
+		event.Raw = slices.Clone(stream.Current)
+		c.resumeToken = slices.Clone(stream.ResumeToken())
+		sequence := c.tracker.dispatch()
+
+		if event.OperationType == OperationTypeInvalidate {
+			c.tracker.complete(sequence, event.ID)
+			c.options.invalidateHandler(event)
+
+			return nil
+		}
+
+		select {
+		case c.eventChannel <- changeEventEnvelope{sequence: sequence, event: event}:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
+	return stream.Err()
+}
+
+// saveResumeTokens periodically saves the last processed resume token until ctx is done.
+func (c *CommonChangeStreamConsumer) saveResumeTokens(ctx context.Context) {
+	ticker := time.NewTicker(c.options.resumeTokenSaveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.saveResumeToken(ctx); err != nil {
+				c.options.errorHandler(err)
+			}
+		}
+	}
+}
+
+// saveResumeToken saves the last processed resume token, if it has changed since previous save.
+func (c *CommonChangeStreamConsumer) saveResumeToken(ctx context.Context) error {
+	token, version := c.tracker.committedToken()
+	if version == c.savedVersion {
+		return nil
+	}
+
+	if err := c.options.resumeTokenStore.Save(ctx, c.name, token); err != nil {
+		return err
+	}
+
+	c.savedVersion = version
+
+	return nil
+}
+
+// isChangeStreamUnresumableError checks, whether change stream can not be resumed from provided token.
+func isChangeStreamUnresumableError(err error) bool {
+	var commandErr mongo.CommandError
+	if !errors.As(err, &commandErr) {
+		return false
+	}
+
+	return commandErr.Code == changeStreamHistoryLostErrorCode || commandErr.Code == changeStreamFatalErrorCode
+}
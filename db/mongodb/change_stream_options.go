@@ -0,0 +1,200 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	mongoOptions "go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	defaultChangeStreamEventChannelBufferSize = 1
+	defaultChangeStreamGoroutinesPoolSize     = 1
+	defaultResumeTokenSaveInterval            = time.Second
+	defaultChangeStreamReconnectBackoff       = 100 * time.Millisecond
+	defaultChangeStreamMaxReconnectBackoff    = 5 * time.Second
+)
+
+var (
+	defaultChangeEventHandler = func(_ context.Context, event *ChangeEvent) {
+		fmt.Printf("mongo change event: %s\n", event.Raw.String())
+	}
+
+	defaultChangeStreamErrorHandler = func(err error) {
+		fmt.Printf("mongo change stream error: %v\n", err)
+	}
+
+	defaultInvalidateHandler = func(event *ChangeEvent) {
+		fmt.Printf("mongo change stream invalidated: %s\n", event.Raw.String())
+	}
+)
+
+// newChangeStreamOptions creates *changeStreamOptions with default values.
+func newChangeStreamOptions() *changeStreamOptions {
+	return &changeStreamOptions{
+		pipeline:                mongo.Pipeline{},
+		eventChannelBufferSize:  defaultChangeStreamEventChannelBufferSize,
+		goroutinesPoolSize:      defaultChangeStreamGoroutinesPoolSize,
+		eventHandler:            defaultChangeEventHandler,
+		errorHandler:            defaultChangeStreamErrorHandler,
+		invalidateHandler:       defaultInvalidateHandler,
+		resumeTokenStore:        NewMemoryResumeTokenStore(),
+		resumeTokenSaveInterval: defaultResumeTokenSaveInterval,
+		reconnectBackoff:        defaultChangeStreamReconnectBackoff,
+		maxReconnectBackoff:     defaultChangeStreamMaxReconnectBackoff,
+	}
+}
+
+// changeStreamOptions represents options for ChangeStreamConsumer configuration.
+type changeStreamOptions struct {
+	pipeline                mongo.Pipeline
+	eventChannelBufferSize  int
+	goroutinesPoolSize      int
+	eventHandler            func(ctx context.Context, event *ChangeEvent)
+	errorHandler            func(err error)
+	invalidateHandler       func(event *ChangeEvent)
+	resumeTokenStore        ResumeTokenStore
+	resumeTokenSaveInterval time.Duration
+	reconnectBackoff        time.Duration
+	maxReconnectBackoff     time.Duration
+	fullDocument            mongoOptions.FullDocument
+	batchSize               *int32
+	maxAwaitTime            *time.Duration
+}
+
+// ChangeStreamOption represents golang functional option pattern func for ChangeStreamConsumer configuration.
+type ChangeStreamOption func(options *changeStreamOptions) error
+
+// toMongoOptions converts changeStreamOptions to *mongoOptions.ChangeStreamOptions.
+func (o *changeStreamOptions) toMongoOptions() *mongoOptions.ChangeStreamOptions {
+	opts := mongoOptions.ChangeStream()
+
+	if o.fullDocument != "" {
+		opts.SetFullDocument(o.fullDocument)
+	}
+
+	if o.batchSize != nil {
+		opts.SetBatchSize(*o.batchSize)
+	}
+
+	if o.maxAwaitTime != nil {
+		opts.SetMaxAwaitTime(*o.maxAwaitTime)
+	}
+
+	return opts
+}
+
+// WithChangeStreamPipeline sets aggregation pipeline for filtering and transforming change events.
+func WithChangeStreamPipeline(pipeline mongo.Pipeline) ChangeStreamOption {
+	return func(options *changeStreamOptions) error {
+		options.pipeline = pipeline
+
+		return nil
+	}
+}
+
+// WithChangeStreamEventChannelBufferSize sets buffer for channel, where change events are stored for processing.
+func WithChangeStreamEventChannelBufferSize(size int) ChangeStreamOption {
+	return func(options *changeStreamOptions) error {
+		options.eventChannelBufferSize = size
+
+		return nil
+	}
+}
+
+// WithChangeStreamGoroutinesPoolSize sets number of goroutines for processing change events.
+func WithChangeStreamGoroutinesPoolSize(size int) ChangeStreamOption {
+	return func(options *changeStreamOptions) error {
+		options.goroutinesPoolSize = size
+
+		return nil
+	}
+}
+
+// WithChangeEventHandler sets handler for received change event.
+func WithChangeEventHandler(handler func(ctx context.Context, event *ChangeEvent)) ChangeStreamOption {
+	return func(options *changeStreamOptions) error {
+		options.eventHandler = handler
+
+		return nil
+	}
+}
+
+// WithChangeStreamErrorHandler sets handler for errors, which occur during change stream processing,
+// such as connection losses or resume token saving failures.
+func WithChangeStreamErrorHandler(handler func(err error)) ChangeStreamOption {
+	return func(options *changeStreamOptions) error {
+		options.errorHandler = handler
+
+		return nil
+	}
+}
+
+// WithInvalidateHandler sets handler for invalidate event, which is received, when watched collection
+// is dropped or renamed. Change stream is reopened after invalidate event automatically.
+func WithInvalidateHandler(handler func(event *ChangeEvent)) ChangeStreamOption {
+	return func(options *changeStreamOptions) error {
+		options.invalidateHandler = handler
+
+		return nil
+	}
+}
+
+// WithResumeTokenStore sets store for resume tokens, which allows to continue processing from
+// the last processed event after restart. By default, tokens are stored in memory.
+func WithResumeTokenStore(store ResumeTokenStore) ChangeStreamOption {
+	return func(options *changeStreamOptions) error {
+		options.resumeTokenStore = store
+
+		return nil
+	}
+}
+
+// WithResumeTokenSaveInterval sets how often the last processed resume token is saved to ResumeTokenStore.
+func WithResumeTokenSaveInterval(interval time.Duration) ChangeStreamOption {
+	return func(options *changeStreamOptions) error {
+		options.resumeTokenSaveInterval = interval
+
+		return nil
+	}
+}
+
+// WithChangeStreamReconnectBackoff sets initial and maximum delays between attempts to reopen change stream
+// after errors.
+func WithChangeStreamReconnectBackoff(initial, maxBackoff time.Duration) ChangeStreamOption {
+	return func(options *changeStreamOptions) error {
+		options.reconnectBackoff = initial
+		options.maxReconnectBackoff = maxBackoff
+
+		return nil
+	}
+}
+
+// WithChangeStreamFullDocument sets, whether change events for updates should contain full document.
+func WithChangeStreamFullDocument(fullDocument mongoOptions.FullDocument) ChangeStreamOption {
+	return func(options *changeStreamOptions) error {
+		options.fullDocument = fullDocument
+
+		return nil
+	}
+}
+
+// WithChangeStreamBatchSize sets maximum number of events in one batch, received from mongoDB.
+func WithChangeStreamBatchSize(size int32) ChangeStreamOption {
+	return func(options *changeStreamOptions) error {
+		options.batchSize = &size
+
+		return nil
+	}
+}
+
+// WithChangeStreamMaxAwaitTime sets maximum amount of time for server to wait for new events.
+func WithChangeStreamMaxAwaitTime(duration time.Duration) ChangeStreamOption {
+	return func(options *changeStreamOptions) error {
+		options.maxAwaitTime = &duration
+
+		return nil
+	}
+}
@@ -0,0 +1,255 @@
+package mongodb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	mongoOptions "go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestResumeTokenTracker(t *testing.T) {
+	t.Parallel()
+
+	tracker := newResumeTokenTracker()
+	first, second, third := tracker.dispatch(), tracker.dispatch(), tracker.dispatch()
+
+	if token, version := tracker.committedToken(); token != nil || version != 0 {
+		t.Fatalf("committedToken() = %v, %d, want nil, 0", token, version)
+	}
+
+	// Events are processed out of order, so token should not advance past unprocessed event:
+	tracker.complete(third, bson.Raw("third"))
+	tracker.complete(second, bson.Raw("second"))
+
+	if token, _ := tracker.committedToken(); token != nil {
+		t.Fatalf("committedToken() = %q, want nil", token)
+	}
+
+	tracker.complete(first, bson.Raw("first"))
+
+	token, version := tracker.committedToken()
+	if !bytes.Equal(token, bson.Raw("third")) {
+		t.Errorf("committedToken() = %q, want %q", token, "third")
+	}
+
+	if version != 3 {
+		t.Errorf("version = %d, want 3", version)
+	}
+}
+
+func TestMemoryResumeTokenStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewMemoryResumeTokenStore()
+
+	token, err := store.Load(ctx, "consumer")
+	if err != nil || token != nil {
+		t.Fatalf("Load() = %v, %v, want nil, nil", token, err)
+	}
+
+	if err = store.Save(ctx, "consumer", bson.Raw("token")); err != nil {
+		t.Fatalf("Save() unexpected error: %v", err)
+	}
+
+	token, err = store.Load(ctx, "consumer")
+	if err != nil || !bytes.Equal(token, bson.Raw("token")) {
+		t.Errorf("Load() = %q, %v, want %q, nil", token, err, "token")
+	}
+}
+
+func TestNewCollectionResumeTokenStoreNilCollection(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewCollectionResumeTokenStore(nil)
+
+	var nilCollectionErr *NilCollectionError
+	if !errors.As(err, &nilCollectionErr) {
+		t.Errorf("NewCollectionResumeTokenStore() error = %v, want *NilCollectionError", err)
+	}
+}
+
+func TestChangeStreamOptionFunctions(t *testing.T) {
+	t.Parallel()
+
+	opts := newChangeStreamOptions()
+	if opts.goroutinesPoolSize != defaultChangeStreamGoroutinesPoolSize {
+		t.Errorf("goroutinesPoolSize = %d, want %d", opts.goroutinesPoolSize, defaultChangeStreamGoroutinesPoolSize)
+	}
+
+	store := NewMemoryResumeTokenStore()
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.D{{Key: "operationType", Value: "insert"}}}}}
+
+	for _, option := range []ChangeStreamOption{
+		WithChangeStreamPipeline(pipeline),
+		WithChangeStreamEventChannelBufferSize(10),
+		WithChangeStreamGoroutinesPoolSize(5),
+		WithChangeEventHandler(func(context.Context, *ChangeEvent) {}),
+		WithChangeStreamErrorHandler(func(error) {}),
+		WithInvalidateHandler(func(*ChangeEvent) {}),
+		WithResumeTokenStore(store),
+		WithResumeTokenSaveInterval(time.Minute),
+		WithChangeStreamReconnectBackoff(time.Second, time.Minute),
+		WithChangeStreamFullDocument(mongoOptions.UpdateLookup),
+		WithChangeStreamBatchSize(100),
+		WithChangeStreamMaxAwaitTime(time.Second),
+	} {
+		if err := option(opts); err != nil {
+			t.Fatalf("option returned error: %v", err)
+		}
+	}
+
+	if len(opts.pipeline) != 1 {
+		t.Errorf("pipeline length = %d, want 1", len(opts.pipeline))
+	}
+
+	if opts.eventChannelBufferSize != 10 || opts.goroutinesPoolSize != 5 {
+		t.Errorf(
+			"eventChannelBufferSize, goroutinesPoolSize = %d, %d, want 10, 5",
+			opts.eventChannelBufferSize,
+			opts.goroutinesPoolSize,
+		)
+	}
+
+	if opts.resumeTokenStore != store {
+		t.Error("resumeTokenStore was not set")
+	}
+
+	if opts.reconnectBackoff != time.Second || opts.maxReconnectBackoff != time.Minute {
+		t.Errorf("reconnect backoff = %v, %v, want 1s, 1m", opts.reconnectBackoff, opts.maxReconnectBackoff)
+	}
+
+	mongoOpts := opts.toMongoOptions()
+	if mongoOpts.FullDocument == nil || *mongoOpts.FullDocument != mongoOptions.UpdateLookup {
+		t.Errorf("FullDocument = %v, want updateLookup", mongoOpts.FullDocument)
+	}
+
+	if mongoOpts.BatchSize == nil || *mongoOpts.BatchSize != 100 {
+		t.Errorf("BatchSize = %v, want 100", mongoOpts.BatchSize)
+	}
+
+	if mongoOpts.MaxAwaitTime == nil || *mongoOpts.MaxAwaitTime != time.Second {
+		t.Errorf("MaxAwaitTime = %v, want 1s", mongoOpts.MaxAwaitTime)
+	}
+}
+
+func TestNewChangeStreamConsumerNilWatcher(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewChangeStreamConsumer(nil, "consumer")
+
+	var nilCollectionErr *NilCollectionError
+	if !errors.As(err, &nilCollectionErr) {
+		t.Errorf("NewChangeStreamConsumer() error = %v, want *NilCollectionError", err)
+	}
+}
+
+func TestChangeStreamConsumerStopBeforeRun(t *testing.T) {
+	t.Parallel()
+
+	consumer, err := NewChangeStreamConsumer(&mongo.Collection{}, "consumer")
+	if err != nil {
+		t.Fatalf("NewChangeStreamConsumer() unexpected error: %v", err)
+	}
+
+	var alreadyStoppedErr *ChangeStreamAlreadyStoppedError
+	if err = consumer.Stop(); !errors.As(err, &alreadyStoppedErr) {
+		t.Errorf("Stop() error = %v, want *ChangeStreamAlreadyStoppedError", err)
+	}
+}
+
+// failingWatcher is a Watcher, which can not open change stream.
+type failingWatcher struct{}
+
+func (failingWatcher) Watch(context.Context, any, ...*mongoOptions.ChangeStreamOptions) (*mongo.ChangeStream, error) {
+	return nil, errors.New("watch failed")
+}
+
+func TestChangeStreamConsumerConcurrentStop(t *testing.T) {
+	t.Parallel()
+
+	consumer, err := NewChangeStreamConsumer(
+		failingWatcher{},
+		"consumer",
+		WithChangeStreamErrorHandler(func(error) {}),
+	)
+	if err != nil {
+		t.Fatalf("NewChangeStreamConsumer() unexpected error: %v", err)
+	}
+
+	if err = consumer.Run(); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	const stops = 5
+
+	results := make(chan error, stops)
+	for range stops {
+		go func() {
+			results <- consumer.Stop()
+		}()
+	}
+
+	var succeeded int
+
+	for range stops {
+		if err = <-results; err == nil {
+			succeeded++
+		}
+	}
+
+	if succeeded != 1 {
+		t.Errorf("Stop() succeeded %d times, want 1", succeeded)
+	}
+
+	var alreadyRunningErr *ChangeStreamAlreadyRunningError
+	if err = consumer.Run(); !errors.As(err, &alreadyRunningErr) {
+		t.Errorf("Run() error = %v, want *ChangeStreamAlreadyRunningError", err)
+	}
+}
+
+func TestIsChangeStreamUnresumableError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "history lost",
+			err:      mongo.CommandError{Code: changeStreamHistoryLostErrorCode},
+			expected: true,
+		},
+		{
+			name:     "fatal error",
+			err:      mongo.CommandError{Code: changeStreamFatalErrorCode},
+			expected: true,
+		},
+		{
+			name:     "other command error",
+			err:      mongo.CommandError{Code: 1},
+			expected: false,
+		},
+		{
+			name:     "not a command error",
+			err:      errors.New("network error"),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if actual := isChangeStreamUnresumableError(tt.err); actual != tt.expected {
+				t.Errorf("isChangeStreamUnresumableError() = %v, want %v", actual, tt.expected)
+			}
+		})
+	}
+}
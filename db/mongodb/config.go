@@ -4,4 +4,39 @@ package mongodb
 type Config struct {
 	Host string
 	Port int
+
+	// Hosts is a list of "host:port" seeds, which is used instead of Host and Port, if provided.
+	// For SRV connection string the only host should be provided without port.
+	Hosts []string
+
+	// SRV enables "mongodb+srv" scheme, which resolves hosts via DNS SRV record.
+	SRV bool
+
+	Username string
+	Password string
+
+	// Database is a default database for authentication, which is used, if AuthSource is not set.
+	Database      string
+	AuthSource    string
+	AuthMechanism string
+
+	ReplicaSet string
+	AppName    string
+
+	TLS                   bool
+	TLSCAFile             string
+	TLSCertificateKeyFile string
+	TLSInsecure           bool
+
+	// Compressors is a list of compressors for communication with server, such as "zstd", "zlib" or "snappy".
+	Compressors []string
+
+	// RetryWrites enables or disables retryable writes. If nil, driver default is used.
+	RetryWrites *bool
+
+	ReadPreference   string
+	ReadConcernLevel string
+
+	// WriteConcern is a "w" value of write concern, such as "majority" or number of nodes.
+	WriteConcern string
 }
@@ -2,13 +2,18 @@ package mongodb
 
 import (
 	"context"
+	"time"
 
+	"github.com/DKhorkov/libs/db/health"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	mongoOptions "go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
+// disconnectTimeout limits disconnection of client, which failed to connect to database.
+const disconnectTimeout = 5 * time.Second
+
 // New is constructor of CommonConnector. Gets database Config and logging.Logger to create an instance.
 func New(
 	ctx context.Context,
@@ -16,7 +21,15 @@ func New(
 	rp *readpref.ReadPref,
 	opts ...Option,
 ) (*CommonConnector, error) {
-	client, err := connect(ctx, dsn, rp, opts...)
+	var connectOpts options
+	for _, opt := range opts {
+		err := opt(&connectOpts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := connect(ctx, dsn, rp, connectOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -25,6 +38,19 @@ func New(
 		client: client,
 	}
 
+	if connectOpts.healthCheckInterval > 0 {
+		dbConnector.healthChecker = health.NewChecker(
+			func(ctx context.Context) error {
+				return client.Ping(ctx, rp)
+			},
+			connectOpts.healthCheckInterval,
+			connectOpts.healthCheckTimeout,
+			connectOpts.healthCheckCallback,
+		)
+
+		dbConnector.healthChecker.Run()
+	}
+
 	return dbConnector, nil
 }
 
@@ -33,26 +59,12 @@ func connect(
 	ctx context.Context,
 	dsn string,
 	rp *readpref.ReadPref,
-	opts ...Option,
+	connectOpts options,
 ) (*mongo.Client, error) {
-	var connectOpts options
-	for _, opt := range opts {
-		err := opt(&connectOpts)
-		if err != nil {
-			return nil, err
-		}
-	}
-
 	clientOptions := mongoOptions.Client().ApplyURI(dsn)
 
-	if connectOpts.username != "" && connectOpts.password != "" && connectOpts.authSource != "" {
-		clientOptions.SetAuth(
-			mongoOptions.Credential{
-				Username:   connectOpts.username,
-				Password:   connectOpts.password,
-				AuthSource: connectOpts.authSource,
-			},
-		)
+	if credential, ok := mergeCredential(clientOptions.Auth, connectOpts); ok {
+		clientOptions.SetAuth(credential)
 	}
 
 	clientOptions.SetMaxConnecting(connectOpts.maxConnections)
@@ -60,23 +72,117 @@ func connect(
 	clientOptions.SetMaxConnIdleTime(connectOpts.maxConnectionIdleTimeout)
 	clientOptions.SetMaxPoolSize(connectOpts.maxPoolSize)
 	clientOptions.SetMinPoolSize(connectOpts.minPoolSize)
+	applyDeploymentOptions(clientOptions, connectOpts)
+
+	if observer := newCommandObserver(connectOpts); observer != nil {
+		clientOptions.SetMonitor(observer.commandMonitor())
+
+		if poolMonitor := observer.poolMonitor(); poolMonitor != nil {
+			clientOptions.SetPoolMonitor(poolMonitor)
+		}
+	}
 
 	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
 		return nil, err
 	}
 
-	// Проверка соединения
-	if err = client.Ping(ctx, rp); err != nil {
+	// Проверка соединения с ожиданием запуска БД:
+	err = health.Retry(
+		ctx,
+		connectOpts.retry,
+		func(ctx context.Context) error {
+			return client.Ping(ctx, rp)
+		},
+	)
+	if err != nil {
+		// ctx can be already done after failed startup, but client resources should be released anyway:
+		disconnectCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), disconnectTimeout)
+		defer cancel()
+
+		_ = client.Disconnect(disconnectCtx)
+
 		return nil, err
 	}
 
 	return client, nil
 }
 
+// mergeCredential returns credential, parsed from DSN, with fields, which were provided explicitly via options,
+// replaced. Reports, whether there is any credential to set.
+func mergeCredential(dsnCredential *mongoOptions.Credential, connectOpts options) (mongoOptions.Credential, bool) {
+	var credential mongoOptions.Credential
+	if dsnCredential != nil {
+		credential = *dsnCredential
+	}
+
+	if connectOpts.username != "" {
+		credential.Username = connectOpts.username
+	}
+
+	if connectOpts.password != "" {
+		credential.Password = connectOpts.password
+		credential.PasswordSet = true
+	}
+
+	if connectOpts.authSource != "" {
+		credential.AuthSource = connectOpts.authSource
+	}
+
+	if connectOpts.authMechanism != "" {
+		credential.AuthMechanism = connectOpts.authMechanism
+	}
+
+	hasOptions := connectOpts.username != "" || connectOpts.password != "" ||
+		connectOpts.authSource != "" || connectOpts.authMechanism != ""
+
+	return credential, dsnCredential != nil || hasOptions
+}
+
+// applyDeploymentOptions applies topology, TLS and default concerns options, which were provided
+// explicitly, over the options from DSN.
+func applyDeploymentOptions(clientOptions *mongoOptions.ClientOptions, connectOpts options) {
+	if len(connectOpts.hosts) > 0 {
+		clientOptions.SetHosts(connectOpts.hosts)
+	}
+
+	if connectOpts.replicaSet != "" {
+		clientOptions.SetReplicaSet(connectOpts.replicaSet)
+	}
+
+	if connectOpts.appName != "" {
+		clientOptions.SetAppName(connectOpts.appName)
+	}
+
+	if len(connectOpts.compressors) > 0 {
+		clientOptions.SetCompressors(connectOpts.compressors)
+	}
+
+	if connectOpts.retryWrites != nil {
+		clientOptions.SetRetryWrites(*connectOpts.retryWrites)
+	}
+
+	if connectOpts.tlsConfig != nil {
+		clientOptions.SetTLSConfig(connectOpts.tlsConfig)
+	}
+
+	if connectOpts.readPreference != nil {
+		clientOptions.SetReadPreference(connectOpts.readPreference)
+	}
+
+	if connectOpts.readConcern != nil {
+		clientOptions.SetReadConcern(connectOpts.readConcern)
+	}
+
+	if connectOpts.writeConcern != nil {
+		clientOptions.SetWriteConcern(connectOpts.writeConcern)
+	}
+}
+
 // CommonConnector is base connector to work with database.
 type CommonConnector struct {
-	client *mongo.Client
+	client        *mongo.Client
+	healthChecker *health.Checker
 }
 
 // Database returns mongo.Database.
@@ -141,8 +247,26 @@ func (c *CommonConnector) ListDatabaseNames(
 	return c.client.ListDatabaseNames(ctx, filter, opts...)
 }
 
+// Healthy returns mongoDB health status. If health checks are disabled, connector is considered healthy
+// while client exists.
+func (c *CommonConnector) Healthy() bool {
+	if c.client == nil {
+		return false
+	}
+
+	if c.healthChecker == nil {
+		return true
+	}
+
+	return c.healthChecker.Healthy()
+}
+
 // Close closes pool of connections.
 func (c *CommonConnector) Close(ctx context.Context) error {
+	if c.healthChecker != nil {
+		c.healthChecker.Stop()
+	}
+
 	if c.client == nil {
 		return nil
 	}
@@ -8,7 +8,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 	"testing"
 	"time"
 )
@@ -156,6 +158,145 @@ func TestOptionFunctions(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:   "WithConnectionRetryDeadline",
+			option: WithConnectionRetryDeadline(30 * time.Second),
+			validateOpts: func(t *testing.T, opts *options) {
+				if opts.retry.Deadline != 30*time.Second {
+					t.Errorf("retry.Deadline = %v, want 30s", opts.retry.Deadline)
+				}
+			},
+		},
+		{
+			name:   "WithConnectionRetryBackoff",
+			option: WithConnectionRetryBackoff(time.Second, 5*time.Second),
+			validateOpts: func(t *testing.T, opts *options) {
+				if opts.retry.InitialBackoff != time.Second || opts.retry.MaxBackoff != 5*time.Second {
+					t.Errorf(
+						"retry backoff = %v/%v, want 1s/5s",
+						opts.retry.InitialBackoff,
+						opts.retry.MaxBackoff,
+					)
+				}
+			},
+		},
+		{
+			name:   "WithHealthCheckInterval",
+			option: WithHealthCheckInterval(10 * time.Second),
+			validateOpts: func(t *testing.T, opts *options) {
+				if opts.healthCheckInterval != 10*time.Second {
+					t.Errorf("healthCheckInterval = %v, want 10s", opts.healthCheckInterval)
+				}
+			},
+		},
+		{
+			name:   "WithHealthCheckTimeout",
+			option: WithHealthCheckTimeout(time.Second),
+			validateOpts: func(t *testing.T, opts *options) {
+				if opts.healthCheckTimeout != time.Second {
+					t.Errorf("healthCheckTimeout = %v, want 1s", opts.healthCheckTimeout)
+				}
+			},
+		},
+		{
+			name:   "WithHosts",
+			option: WithHosts("mongo-1:27017", "mongo-2:27017"),
+			validateOpts: func(t *testing.T, opts *options) {
+				if len(opts.hosts) != 2 {
+					t.Errorf("hosts = %v, want 2 hosts", opts.hosts)
+				}
+			},
+		},
+		{
+			name:   "WithReplicaSet",
+			option: WithReplicaSet("rs0"),
+			validateOpts: func(t *testing.T, opts *options) {
+				if opts.replicaSet != "rs0" {
+					t.Errorf("replicaSet = %s, want rs0", opts.replicaSet)
+				}
+			},
+		},
+		{
+			name:   "WithAuthMechanism",
+			option: WithAuthMechanism("SCRAM-SHA-256"),
+			validateOpts: func(t *testing.T, opts *options) {
+				if opts.authMechanism != "SCRAM-SHA-256" {
+					t.Errorf("authMechanism = %s, want SCRAM-SHA-256", opts.authMechanism)
+				}
+			},
+		},
+		{
+			name:   "WithAppName",
+			option: WithAppName("service"),
+			validateOpts: func(t *testing.T, opts *options) {
+				if opts.appName != "service" {
+					t.Errorf("appName = %s, want service", opts.appName)
+				}
+			},
+		},
+		{
+			name:   "WithCompressors",
+			option: WithCompressors("zstd", "snappy"),
+			validateOpts: func(t *testing.T, opts *options) {
+				if len(opts.compressors) != 2 {
+					t.Errorf("compressors = %v, want 2 compressors", opts.compressors)
+				}
+			},
+		},
+		{
+			name:   "WithRetryWrites",
+			option: WithRetryWrites(false),
+			validateOpts: func(t *testing.T, opts *options) {
+				if opts.retryWrites == nil || *opts.retryWrites {
+					t.Errorf("retryWrites = %v, want false", opts.retryWrites)
+				}
+			},
+		},
+		{
+			name:   "WithTLSFiles without files",
+			option: WithTLSFiles("", ""),
+			validateOpts: func(t *testing.T, opts *options) {
+				if opts.tlsConfig == nil {
+					t.Error("tlsConfig = nil, want config")
+				}
+			},
+		},
+		{
+			name:   "WithReadPreference",
+			option: WithReadPreference(readpref.SecondaryPreferred()),
+			validateOpts: func(t *testing.T, opts *options) {
+				if opts.readPreference == nil {
+					t.Error("readPreference = nil, want secondaryPreferred")
+				}
+			},
+		},
+		{
+			name:   "WithReadConcern",
+			option: WithReadConcern(readconcern.Majority()),
+			validateOpts: func(t *testing.T, opts *options) {
+				if opts.readConcern == nil {
+					t.Error("readConcern = nil, want majority")
+				}
+			},
+		},
+		{
+			name:   "WithWriteConcern",
+			option: WithWriteConcern(writeconcern.Majority()),
+			validateOpts: func(t *testing.T, opts *options) {
+				if opts.writeConcern == nil {
+					t.Error("writeConcern = nil, want majority")
+				}
+			},
+		},
+		{
+			name:   "WithHealthCheckCallback",
+			option: WithHealthCheckCallback(func(_ bool) {}),
+			validateOpts: func(t *testing.T, opts *options) {
+				if opts.healthCheckCallback == nil {
+					t.Error("healthCheckCallback = nil, want callback")
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -344,6 +485,51 @@ func TestPing(t *testing.T) {
 	}
 }
 
+func TestHealthy(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupConnector func() *CommonConnector
+		expected       bool
+	}{
+		{
+			name: "healthy with health checks",
+			setupConnector: func() *CommonConnector {
+				c, err := New(
+					context.Background(),
+					"mongodb://localhost:27017",
+					nil,
+					WithUsername("admin"),
+					WithPassword("secret"),
+					WithAuthSource("admin"),
+					WithConnectionRetryDeadline(5*time.Second),
+					WithHealthCheckInterval(100*time.Millisecond),
+				)
+
+				require.NoError(t, err)
+				return c
+			},
+			expected: true,
+		},
+		{
+			name: "nil client",
+			setupConnector: func() *CommonConnector {
+				return &CommonConnector{
+					client: nil,
+				}
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connector := tt.setupConnector()
+			require.Equal(t, tt.expected, connector.Healthy())
+			require.NoError(t, connector.Close(context.Background()))
+		})
+	}
+}
+
 func TestClose(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -47,3 +47,144 @@ func (e NilDatabaseError) Error() string {
 func (e NilDatabaseError) Unwrap() error {
 	return e.BaseErr
 }
+
+// NotFoundError is an error, representing that no documents were found in mongo.Collection.
+type NotFoundError struct {
+	Message string
+	BaseErr error
+}
+
+func (e NotFoundError) Error() string {
+	template := "Mongo document error. Document not found"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e NotFoundError) Unwrap() error {
+	return e.BaseErr
+}
+
+// NilCollectionError is an error, representing no mongo.Collection provided.
+type NilCollectionError struct {
+	Message string
+	BaseErr error
+}
+
+func (e NilCollectionError) Error() string {
+	template := "Mongo Collection error. Making operation on nil Collection"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e NilCollectionError) Unwrap() error {
+	return e.BaseErr
+}
+
+// InvalidMigrationError is an error, representing invalid migrations set, provided to Migrator.
+type InvalidMigrationError struct {
+	Message string
+	BaseErr error
+}
+
+func (e InvalidMigrationError) Error() string {
+	template := "Mongo migration error. Invalid migration provided"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e InvalidMigrationError) Unwrap() error {
+	return e.BaseErr
+}
+
+// MigrationLockError is an error, representing that migrations lock was not acquired, because it is held
+// by another replica.
+type MigrationLockError struct {
+	Message string
+	BaseErr error
+}
+
+func (e MigrationLockError) Error() string {
+	template := "Mongo migration error. Failed to acquire migrations lock"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e MigrationLockError) Unwrap() error {
+	return e.BaseErr
+}
+
+// ChangeStreamAlreadyRunningError is an error, which represents, that change stream consumer was already started
+// and can not be started again.
+type ChangeStreamAlreadyRunningError struct {
+	Message string
+	BaseErr error
+}
+
+func (e ChangeStreamAlreadyRunningError) Error() string {
+	template := "Mongo change stream error. Consumer is already running"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e ChangeStreamAlreadyRunningError) Unwrap() error {
+	return e.BaseErr
+}
+
+// ChangeStreamAlreadyStoppedError is an error, which represents, that change stream consumer was not started yet
+// or was already stopped.
+type ChangeStreamAlreadyStoppedError struct {
+	Message string
+	BaseErr error
+}
+
+func (e ChangeStreamAlreadyStoppedError) Error() string {
+	template := "Mongo change stream error. Consumer is already stopped"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e ChangeStreamAlreadyStoppedError) Unwrap() error {
+	return e.BaseErr
+}
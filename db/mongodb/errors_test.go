@@ -277,3 +277,196 @@ func TestErrorsIsChain(t *testing.T) {
 		})
 	}
 }
+
+func TestNotFoundError(t *testing.T) {
+	t.Parallel()
+
+	baseErr := errors.New("mongo: no documents in result")
+
+	tests := []struct {
+		name        string
+		err         *NotFoundError
+		expectedErr string
+	}{
+		{
+			name:        "empty error with no base error",
+			err:         &NotFoundError{},
+			expectedErr: "Mongo document error. Document not found",
+		},
+		{
+			name: "custom message with no base error",
+			err: &NotFoundError{
+				Message: "User not found",
+			},
+			expectedErr: "User not found",
+		},
+		{
+			name: "empty message with base error",
+			err: &NotFoundError{
+				BaseErr: baseErr,
+			},
+			expectedErr: "Mongo document error. Document not found. Base error: mongo: no documents in result",
+		},
+		{
+			name: "custom message with base error",
+			err: &NotFoundError{
+				Message: "User not found",
+				BaseErr: baseErr,
+			},
+			expectedErr: "User not found. Base error: mongo: no documents in result",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if errorString := tt.err.Error(); errorString != tt.expectedErr {
+				t.Errorf("Error() = %q, want %q", errorString, tt.expectedErr)
+			}
+
+			if unwrapped := tt.err.Unwrap(); !errors.Is(unwrapped, tt.err.BaseErr) {
+				t.Errorf("Unwrap() = %v, want %v", unwrapped, tt.err.BaseErr)
+			}
+		})
+	}
+}
+
+func TestNilCollectionError(t *testing.T) {
+	t.Parallel()
+
+	baseErr := errors.New("collection is not initialized")
+
+	tests := []struct {
+		name        string
+		err         *NilCollectionError
+		expectedErr string
+	}{
+		{
+			name:        "empty error with no base error",
+			err:         &NilCollectionError{},
+			expectedErr: "Mongo Collection error. Making operation on nil Collection",
+		},
+		{
+			name: "custom message with no base error",
+			err: &NilCollectionError{
+				Message: "Custom collection error",
+			},
+			expectedErr: "Custom collection error",
+		},
+		{
+			name: "empty message with base error",
+			err: &NilCollectionError{
+				BaseErr: baseErr,
+			},
+			expectedErr: "Mongo Collection error. Making operation on nil Collection. Base error: collection is not initialized",
+		},
+		{
+			name: "custom message with base error",
+			err: &NilCollectionError{
+				Message: "Custom collection error",
+				BaseErr: baseErr,
+			},
+			expectedErr: "Custom collection error. Base error: collection is not initialized",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if errorString := tt.err.Error(); errorString != tt.expectedErr {
+				t.Errorf("Error() = %q, want %q", errorString, tt.expectedErr)
+			}
+
+			if unwrapped := tt.err.Unwrap(); !errors.Is(unwrapped, tt.err.BaseErr) {
+				t.Errorf("Unwrap() = %v, want %v", unwrapped, tt.err.BaseErr)
+			}
+		})
+	}
+}
+
+func TestMigrationErrors(t *testing.T) {
+	t.Parallel()
+
+	baseErr := errors.New("context deadline exceeded")
+
+	tests := []struct {
+		name        string
+		err         error
+		expectedErr string
+	}{
+		{
+			name:        "invalid migration error with default message",
+			err:         &InvalidMigrationError{},
+			expectedErr: "Mongo migration error. Invalid migration provided",
+		},
+		{
+			name:        "invalid migration error with custom message",
+			err:         &InvalidMigrationError{Message: "Migration version 1 is duplicated"},
+			expectedErr: "Migration version 1 is duplicated",
+		},
+		{
+			name:        "migration lock error with default message",
+			err:         &MigrationLockError{},
+			expectedErr: "Mongo migration error. Failed to acquire migrations lock",
+		},
+		{
+			name:        "migration lock error with base error",
+			err:         &MigrationLockError{BaseErr: baseErr},
+			expectedErr: "Mongo migration error. Failed to acquire migrations lock. Base error: context deadline exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if errorString := tt.err.Error(); errorString != tt.expectedErr {
+				t.Errorf("Error() = %q, want %q", errorString, tt.expectedErr)
+			}
+		})
+	}
+
+	if !errors.Is(&MigrationLockError{BaseErr: baseErr}, baseErr) {
+		t.Error("errors.Is should return true for base error")
+	}
+}
+
+func TestChangeStreamErrors(t *testing.T) {
+	t.Parallel()
+
+	baseErr := errors.New("underlying error")
+
+	tests := []struct {
+		name        string
+		err         error
+		expectedErr string
+	}{
+		{
+			name:        "already running error",
+			err:         &ChangeStreamAlreadyRunningError{},
+			expectedErr: "Mongo change stream error. Consumer is already running",
+		},
+		{
+			name:        "already stopped error",
+			err:         &ChangeStreamAlreadyStoppedError{},
+			expectedErr: "Mongo change stream error. Consumer is already stopped",
+		},
+		{
+			name:        "already stopped error with base error",
+			err:         &ChangeStreamAlreadyStoppedError{Message: "Custom", BaseErr: baseErr},
+			expectedErr: "Custom. Base error: underlying error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if errorString := tt.err.Error(); errorString != tt.expectedErr {
+				t.Errorf("Error() = %q, want %q", errorString, tt.expectedErr)
+			}
+		})
+	}
+}
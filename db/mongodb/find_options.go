@@ -0,0 +1,93 @@
+package mongodb
+
+import (
+	"errors"
+
+	mongoOptions "go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var errInvalidPagination = errors.New("page and page size should be positive")
+
+// findOptions represents options for Repository.Find configuration.
+type findOptions struct {
+	sort       any
+	projection any
+	limit      int64
+	skip       int64
+}
+
+// FindOption represents golang functional option pattern func for Repository.Find configuration.
+type FindOption func(options *findOptions) error
+
+// toMongoOptions converts findOptions to *mongoOptions.FindOptions.
+func (o *findOptions) toMongoOptions() *mongoOptions.FindOptions {
+	opts := mongoOptions.Find()
+
+	if o.sort != nil {
+		opts.SetSort(o.sort)
+	}
+
+	if o.projection != nil {
+		opts.SetProjection(o.projection)
+	}
+
+	if o.limit > 0 {
+		opts.SetLimit(o.limit)
+	}
+
+	if o.skip > 0 {
+		opts.SetSkip(o.skip)
+	}
+
+	return opts
+}
+
+// WithFindSort sets order of found documents, for example bson.D{{"createdAt", -1}}.
+func WithFindSort(sort any) FindOption {
+	return func(options *findOptions) error {
+		options.sort = sort
+
+		return nil
+	}
+}
+
+// WithFindProjection sets fields of found documents to return, for example bson.D{{"password", 0}}.
+func WithFindProjection(projection any) FindOption {
+	return func(options *findOptions) error {
+		options.projection = projection
+
+		return nil
+	}
+}
+
+// WithFindLimit sets maximum number of documents to return.
+func WithFindLimit(limit int64) FindOption {
+	return func(options *findOptions) error {
+		options.limit = limit
+
+		return nil
+	}
+}
+
+// WithFindSkip sets number of documents to skip before returning.
+func WithFindSkip(skip int64) FindOption {
+	return func(options *findOptions) error {
+		options.skip = skip
+
+		return nil
+	}
+}
+
+// WithFindPagination sets limit and skip on base of page number, starting from 1, and page size.
+func WithFindPagination(page, pageSize int64) FindOption {
+	return func(options *findOptions) error {
+		if page < 1 || pageSize < 1 {
+			return errInvalidPagination
+		}
+
+		options.limit = pageSize
+		options.skip = (page - 1) * pageSize
+
+		return nil
+	}
+}
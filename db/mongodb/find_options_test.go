@@ -0,0 +1,93 @@
+package mongodb
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestFindOptionFunctions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		options      []FindOption
+		wantErr      bool
+		validateOpts func(*testing.T, *findOptions)
+	}{
+		{
+			name:    "WithFindSort",
+			options: []FindOption{WithFindSort(bson.D{{Key: "name", Value: 1}})},
+			validateOpts: func(t *testing.T, opts *findOptions) {
+				if opts.sort == nil {
+					t.Error("sort = nil, want bson.D")
+				}
+			},
+		},
+		{
+			name:    "WithFindProjection",
+			options: []FindOption{WithFindProjection(bson.D{{Key: "password", Value: 0}})},
+			validateOpts: func(t *testing.T, opts *findOptions) {
+				if opts.projection == nil {
+					t.Error("projection = nil, want bson.D")
+				}
+			},
+		},
+		{
+			name:    "WithFindLimit and WithFindSkip",
+			options: []FindOption{WithFindLimit(10), WithFindSkip(20)},
+			validateOpts: func(t *testing.T, opts *findOptions) {
+				if opts.limit != 10 || opts.skip != 20 {
+					t.Errorf("limit/skip = %d/%d, want 10/20", opts.limit, opts.skip)
+				}
+			},
+		},
+		{
+			name:    "WithFindPagination",
+			options: []FindOption{WithFindPagination(3, 25)},
+			validateOpts: func(t *testing.T, opts *findOptions) {
+				if opts.limit != 25 || opts.skip != 50 {
+					t.Errorf("limit/skip = %d/%d, want 25/50", opts.limit, opts.skip)
+				}
+			},
+		},
+		{
+			name:    "WithFindPagination with invalid page",
+			options: []FindOption{WithFindPagination(0, 25)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := &findOptions{}
+			for _, option := range tt.options {
+				err := option(opts)
+				if tt.wantErr {
+					if err == nil {
+						t.Error("option should return error")
+					}
+
+					return
+				}
+
+				if err != nil {
+					t.Errorf("option returned error: %v", err)
+				}
+			}
+
+			tt.validateOpts(t, opts)
+
+			mongoOpts := opts.toMongoOptions()
+			if opts.limit > 0 && (mongoOpts.Limit == nil || *mongoOpts.Limit != opts.limit) {
+				t.Errorf("mongo options limit = %v, want %d", mongoOpts.Limit, opts.limit)
+			}
+
+			if opts.skip > 0 && (mongoOpts.Skip == nil || *mongoOpts.Skip != opts.skip) {
+				t.Errorf("mongo options skip = %v, want %d", mongoOpts.Skip, opts.skip)
+			}
+		})
+	}
+}
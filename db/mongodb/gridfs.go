@@ -0,0 +1,413 @@
+package mongodb
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	mongoOptions "go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	contentTypeMetadataField = "contentType"
+	checksumMetadataField    = "sha256"
+)
+
+var errNegativeFileOffset = errors.New("negative file offset")
+
+// FileInfo represents file, stored in GridFS.
+type FileInfo struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	Name        string             `bson:"filename"`
+	Length      int64              `bson:"length"`
+	ChunkSize   int32              `bson:"chunkSize"`
+	UploadDate  time.Time          `bson:"uploadDate"`
+	Metadata    bson.Raw           `bson:"metadata,omitempty"`
+	ContentType string             `bson:"-"`
+	Checksum    string             `bson:"-"`
+}
+
+// ETag returns strong entity tag of file content, based on SHA-256 checksum. For files, which were uploaded
+// without FileStorage and have no checksum, tag is built from ID, length and upload date.
+func (i *FileInfo) ETag() string {
+	if i.Checksum != "" {
+		return fmt.Sprintf("%q", i.Checksum)
+	}
+
+	return fmt.Sprintf("%q", fmt.Sprintf("%s-%d-%d", i.ID.Hex(), i.Length, i.UploadDate.UnixNano()))
+}
+
+// fillFromMetadata fills ContentType and Checksum from file metadata.
+func (i *FileInfo) fillFromMetadata() {
+	if i.Metadata == nil {
+		return
+	}
+
+	if contentType, ok := i.Metadata.Lookup(contentTypeMetadataField).StringValueOK(); ok {
+		i.ContentType = contentType
+	}
+
+	if checksum, ok := i.Metadata.Lookup(checksumMetadataField).StringValueOK(); ok {
+		i.Checksum = checksum
+	}
+}
+
+// FileStorage stores files of any size in mongoDB via GridFS.
+type FileStorage struct {
+	bucket *gridfs.Bucket
+}
+
+// NewFileStorage creates *FileStorage, which stores files in GridFS bucket of provided database.
+func NewFileStorage(connector Connector, database string, opts ...FileStorageOption) (*FileStorage, error) {
+	options := newFileStorageOptions()
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, err
+		}
+	}
+
+	db, err := connector.Database(database)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket, err := gridfs.NewBucket(
+		db,
+		mongoOptions.GridFSBucket().
+			SetName(options.bucketName).
+			SetChunkSizeBytes(options.chunkSize),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileStorage{bucket: bucket}, nil
+}
+
+// Upload streams content from source to GridFS file with provided name. SHA-256 checksum of content
+// is calculated during upload and stored in file metadata.
+func (s *FileStorage) Upload(
+	ctx context.Context,
+	name string,
+	source io.Reader,
+	opts ...UploadOption,
+) (*FileInfo, error) {
+	options := &uploadOptions{}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, err
+		}
+	}
+
+	metadata := bson.M{}
+	maps.Copy(metadata, options.metadata)
+
+	if options.contentType != "" {
+		metadata[contentTypeMetadataField] = options.contentType
+	}
+
+	stream, err := s.bucket.OpenUploadStream(name, mongoOptions.GridFSUpload().SetMetadata(metadata))
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = stream.SetWriteDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	hash := sha256.New()
+	if _, err = io.Copy(stream, io.TeeReader(source, hash)); err != nil {
+		_ = stream.Abort()
+
+		return nil, err
+	}
+
+	if err = stream.Close(); err != nil {
+		return nil, err
+	}
+
+	id, ok := stream.FileID.(primitive.ObjectID)
+	if !ok {
+		return nil, fmt.Errorf("unexpected GridFS file ID type %T", stream.FileID)
+	}
+
+	_, err = s.bucket.GetFilesCollection().UpdateOne(
+		ctx,
+		bson.D{{Key: idField, Value: id}},
+		bson.D{
+			{Key: "$set", Value: bson.D{
+				{Key: "metadata." + checksumMetadataField, Value: hex.EncodeToString(hash.Sum(nil))},
+			}},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Stat(ctx, id)
+}
+
+// Download writes content of file with provided ID to destination. Returns number of written bytes.
+func (s *FileStorage) Download(ctx context.Context, id primitive.ObjectID, destination io.Writer) (int64, error) {
+	file, err := s.Open(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return io.Copy(destination, file)
+}
+
+// Open opens file with provided ID for reading. File supports seeking, so only chunks,
+// which contain requested bytes, are loaded from mongoDB. Provided ctx is used for all reads.
+func (s *FileStorage) Open(ctx context.Context, id primitive.ObjectID) (*File, error) {
+	info, err := s.Stat(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &File{
+		ctx:    ctx,
+		info:   info,
+		chunks: s.bucket.GetChunksCollection(),
+	}, nil
+}
+
+// OpenRange opens reader for length bytes of file with provided ID, starting from offset.
+// Can be used for serving HTTP partial content.
+func (s *FileStorage) OpenRange(
+	ctx context.Context,
+	id primitive.ObjectID,
+	offset int64,
+	length int64,
+) (io.ReadCloser, error) {
+	file, err := s.Open(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = file.Seek(offset, io.SeekStart); err != nil {
+		_ = file.Close()
+
+		return nil, err
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: io.LimitReader(file, length),
+		Closer: file,
+	}, nil
+}
+
+// Stat returns information about file with provided ID. Returns NotFoundError, if file does not exist.
+func (s *FileStorage) Stat(ctx context.Context, id primitive.ObjectID) (*FileInfo, error) {
+	info := &FileInfo{}
+
+	err := s.bucket.GetFilesCollection().FindOne(ctx, bson.D{{Key: idField, Value: id}}).Decode(info)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, &NotFoundError{Message: "Mongo GridFS error. File not found", BaseErr: err}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	info.fillFromMetadata()
+
+	return info, nil
+}
+
+// List returns information about files, which match provided filter.
+func (s *FileStorage) List(
+	ctx context.Context,
+	filter any,
+	opts ...*mongoOptions.GridFSFindOptions,
+) ([]FileInfo, error) {
+	cursor, err := s.bucket.FindContext(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]FileInfo, 0)
+	if err = cursor.All(ctx, &files); err != nil {
+		return nil, err
+	}
+
+	for i := range files {
+		files[i].fillFromMetadata()
+	}
+
+	return files, nil
+}
+
+// Delete deletes file with provided ID and all its chunks. Returns NotFoundError, if file does not exist.
+func (s *FileStorage) Delete(ctx context.Context, id primitive.ObjectID) error {
+	err := s.bucket.DeleteContext(ctx, id)
+	if errors.Is(err, gridfs.ErrFileNotFound) {
+		return &NotFoundError{Message: "Mongo GridFS error. File not found", BaseErr: err}
+	}
+
+	return err
+}
+
+// chunk represents GridFS chunk document.
+type chunk struct {
+	N    int64  `bson:"n"`
+	Data []byte `bson:"data"`
+}
+
+// File is a GridFS file, opened for reading. File implements io.ReadSeekCloser and can be served
+// via http.ServeContent.
+type File struct {
+	ctx    context.Context //nolint:containedctx // File implements io.Reader, which has no context argument
+	info   *FileInfo
+	chunks *mongo.Collection
+	offset int64
+	cursor *mongo.Cursor
+	chunkN int64
+	buffer []byte
+}
+
+// Info returns information about opened file.
+func (f *File) Info() *FileInfo {
+	return f.info
+}
+
+// Read reads up to len(p) bytes of file content, loading chunks from mongoDB on demand.
+func (f *File) Read(p []byte) (int, error) {
+	if f.offset >= f.info.Length {
+		return 0, io.EOF
+	}
+
+	if len(f.buffer) == 0 {
+		if err := f.fillBuffer(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, f.buffer)
+	f.buffer = f.buffer[n:]
+	f.offset += int64(n)
+
+	return n, nil
+}
+
+// Seek sets offset for next Read. Chunks are loaded starting from chunk, which contains new offset.
+func (f *File) Seek(offset int64, whence int) (int64, error) {
+	var newOffset int64
+
+	switch whence {
+	case io.SeekStart:
+		newOffset = offset
+	case io.SeekCurrent:
+		newOffset = f.offset + offset
+	case io.SeekEnd:
+		newOffset = f.info.Length + offset
+	default:
+		return f.offset, fmt.Errorf("invalid whence %d", whence)
+	}
+
+	if newOffset < 0 {
+		return f.offset, errNegativeFileOffset
+	}
+
+	if newOffset != f.offset {
+		if err := f.closeCursor(); err != nil {
+			return f.offset, err
+		}
+
+		f.offset = newOffset
+	}
+
+	return f.offset, nil
+}
+
+// Close releases cursor over file chunks.
+func (f *File) Close() error {
+	return f.closeCursor()
+}
+
+// fillBuffer loads next chunk to buffer, opening cursor from current offset, if needed.
+func (f *File) fillBuffer() error {
+	skip := 0
+
+	if f.cursor == nil {
+		if f.info.ChunkSize <= 0 {
+			return gridfs.ErrMissingChunkSize
+		}
+
+		chunkSize := int64(f.info.ChunkSize)
+		f.chunkN = f.offset / chunkSize
+		skip = int(f.offset % chunkSize)
+
+		cursor, err := f.chunks.Find(
+			f.ctx,
+			bson.D{
+				{Key: "files_id", Value: f.info.ID},
+				{Key: "n", Value: bson.D{{Key: "$gte", Value: f.chunkN}}},
+			},
+			mongoOptions.Find().SetSort(bson.D{{Key: "n", Value: 1}}),
+		)
+		if err != nil {
+			return err
+		}
+
+		f.cursor = cursor
+	}
+
+	if !f.cursor.Next(f.ctx) {
+		if err := f.cursor.Err(); err != nil {
+			return err
+		}
+
+		return io.ErrUnexpectedEOF
+	}
+
+	var current chunk
+	if err := f.cursor.Decode(&current); err != nil {
+		return err
+	}
+
+	if current.N != f.chunkN {
+		return gridfs.ErrWrongIndex
+	}
+
+	if skip > len(current.Data) {
+		return gridfs.ErrWrongSize
+	}
+
+	f.chunkN++
+	f.buffer = current.Data[skip:]
+
+	return nil
+}
+
+// closeCursor closes cursor over file chunks and drops buffered data.
+func (f *File) closeCursor() error {
+	f.buffer = nil
+
+	if f.cursor == nil {
+		return nil
+	}
+
+	cursor := f.cursor
+	f.cursor = nil
+
+	return cursor.Close(context.WithoutCancel(f.ctx))
+}
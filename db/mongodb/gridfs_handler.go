@@ -0,0 +1,68 @@
+package mongodb
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FileIDFromPathValue returns func, which extracts GridFS file ID from request path wildcard with provided name,
+// for example "/files/{id}".
+func FileIDFromPathValue(name string) func(r *http.Request) (primitive.ObjectID, error) {
+	return func(r *http.Request) (primitive.ObjectID, error) {
+		return primitive.ObjectIDFromHex(r.PathValue(name))
+	}
+}
+
+// NewFileHandler creates http.Handler, which serves files from FileStorage. ETag of file content is set
+// for every response, so If-None-Match and If-Modified-Since requests are answered with 304 Not Modified,
+// and Range requests are answered with partial content, loading only required chunks.
+func NewFileHandler(
+	storage *FileStorage,
+	fileID func(r *http.Request) (primitive.ObjectID, error),
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", strings.Join([]string{http.MethodGet, http.MethodHead}, ", "))
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		id, err := fileID(r)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+
+			return
+		}
+
+		file, err := storage.Open(r.Context(), id)
+		if err != nil {
+			var notFoundErr *NotFoundError
+			if errors.As(err, &notFoundErr) {
+				http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+
+				return
+			}
+
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		}
+
+		defer func() {
+			_ = file.Close()
+		}()
+
+		info := file.Info()
+		w.Header().Set("ETag", info.ETag())
+
+		if info.ContentType != "" {
+			w.Header().Set("Content-Type", info.ContentType)
+		}
+
+		http.ServeContent(w, r, info.Name, info.UploadDate, file)
+	})
+}
@@ -0,0 +1,104 @@
+//go:build integration
+
+package mongodb
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestFileStorage(t *testing.T) {
+	ctx := context.Background()
+
+	connector, err := New(
+		ctx,
+		"mongodb://localhost:27017",
+		nil,
+		WithUsername("admin"),
+		WithPassword("secret"),
+		WithAuthSource("admin"),
+	)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, connector.Close(ctx))
+	}()
+
+	storage, err := NewFileStorage(connector, "test", WithBucketName("files"), WithChunkSize(4))
+	require.NoError(t, err)
+
+	content := []byte("hello, gridfs world")
+	info, err := storage.Upload(
+		ctx,
+		"hello.txt",
+		bytes.NewReader(content),
+		WithUploadContentType("text/plain"),
+		WithUploadMetadata(bson.M{"owner": "tests"}),
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(content)), info.Length)
+	require.Equal(t, "text/plain", info.ContentType)
+	require.NotEmpty(t, info.Checksum)
+
+	t.Run("download", func(t *testing.T) {
+		var buffer bytes.Buffer
+
+		_, err := storage.Download(ctx, info.ID, &buffer)
+		require.NoError(t, err)
+		require.Equal(t, content, buffer.Bytes())
+	})
+
+	t.Run("range", func(t *testing.T) {
+		reader, err := storage.OpenRange(ctx, info.ID, 7, 6)
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, reader.Close())
+		}()
+
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		require.Equal(t, "gridfs", string(data))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		files, err := storage.List(ctx, bson.D{{Key: "metadata.owner", Value: "tests"}})
+		require.NoError(t, err)
+		require.NotEmpty(t, files)
+	})
+
+	t.Run("http handler", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.Handle("/files/{id}", NewFileHandler(storage, FileIDFromPathValue("id")))
+
+		request := httptest.NewRequest(http.MethodGet, "/files/"+info.ID.Hex(), nil)
+		request.Header.Set("Range", "bytes=0-4")
+
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, request)
+		require.Equal(t, http.StatusPartialContent, recorder.Code)
+		require.Equal(t, "hello", recorder.Body.String())
+		require.Equal(t, info.ETag(), recorder.Header().Get("ETag"))
+
+		request = httptest.NewRequest(http.MethodGet, "/files/"+info.ID.Hex(), nil)
+		request.Header.Set("If-None-Match", info.ETag())
+
+		recorder = httptest.NewRecorder()
+		mux.ServeHTTP(recorder, request)
+		require.Equal(t, http.StatusNotModified, recorder.Code)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, storage.Delete(ctx, info.ID))
+
+		var notFoundErr *NotFoundError
+		require.ErrorAs(t, storage.Delete(ctx, info.ID), &notFoundErr)
+	})
+}
@@ -0,0 +1,76 @@
+package mongodb
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	defaultBucketName = "fs"
+
+	// defaultChunkSize is a default GridFS chunk size of 255 KiB.
+	defaultChunkSize int32 = 255 * 1024
+)
+
+// newFileStorageOptions creates *fileStorageOptions with default values.
+func newFileStorageOptions() *fileStorageOptions {
+	return &fileStorageOptions{
+		bucketName: defaultBucketName,
+		chunkSize:  defaultChunkSize,
+	}
+}
+
+// fileStorageOptions represents options for FileStorage configuration.
+type fileStorageOptions struct {
+	bucketName string
+	chunkSize  int32
+}
+
+// FileStorageOption represents golang functional option pattern func for FileStorage configuration.
+type FileStorageOption func(options *fileStorageOptions) error
+
+// WithBucketName sets name of GridFS bucket. Files and chunks are stored in "<name>.files"
+// and "<name>.chunks" collections.
+func WithBucketName(name string) FileStorageOption {
+	return func(options *fileStorageOptions) error {
+		options.bucketName = name
+
+		return nil
+	}
+}
+
+// WithChunkSize sets size of GridFS chunks in bytes for uploaded files.
+func WithChunkSize(size int32) FileStorageOption {
+	return func(options *fileStorageOptions) error {
+		options.chunkSize = size
+
+		return nil
+	}
+}
+
+// uploadOptions represents options for single file upload.
+type uploadOptions struct {
+	contentType string
+	metadata    bson.M
+}
+
+// UploadOption represents golang functional option pattern func for file upload configuration.
+type UploadOption func(options *uploadOptions) error
+
+// WithUploadContentType sets MIME type of uploaded file, which is used for serving file via HTTP.
+func WithUploadContentType(contentType string) UploadOption {
+	return func(options *uploadOptions) error {
+		options.contentType = contentType
+
+		return nil
+	}
+}
+
+// WithUploadMetadata sets custom metadata of uploaded file. Keys "contentType" and "sha256" are reserved
+// by FileStorage and are overwritten.
+func WithUploadMetadata(metadata bson.M) UploadOption {
+	return func(options *uploadOptions) error {
+		options.metadata = metadata
+
+		return nil
+	}
+}
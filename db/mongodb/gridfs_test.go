@@ -0,0 +1,147 @@
+package mongodb
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestFileInfoETag(t *testing.T) {
+	t.Parallel()
+
+	id := primitive.NewObjectID()
+	uploadDate := time.Unix(0, 42)
+
+	tests := []struct {
+		name     string
+		info     *FileInfo
+		expected string
+	}{
+		{
+			name:     "with checksum",
+			info:     &FileInfo{Checksum: "abc"},
+			expected: `"abc"`,
+		},
+		{
+			name:     "without checksum",
+			info:     &FileInfo{ID: id, Length: 10, UploadDate: uploadDate},
+			expected: `"` + id.Hex() + `-10-42"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if actual := tt.info.ETag(); actual != tt.expected {
+				t.Errorf("ETag() = %s, want %s", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFileInfoFillFromMetadata(t *testing.T) {
+	t.Parallel()
+
+	metadata, err := bson.Marshal(bson.D{
+		{Key: contentTypeMetadataField, Value: "image/png"},
+		{Key: checksumMetadataField, Value: "abc"},
+	})
+	if err != nil {
+		t.Fatalf("bson.Marshal() unexpected error: %v", err)
+	}
+
+	info := &FileInfo{Metadata: metadata}
+	info.fillFromMetadata()
+
+	if info.ContentType != "image/png" || info.Checksum != "abc" {
+		t.Errorf("ContentType, Checksum = %q, %q, want image/png, abc", info.ContentType, info.Checksum)
+	}
+}
+
+func TestFileSeek(t *testing.T) {
+	t.Parallel()
+
+	file := &File{info: &FileInfo{Length: 100, ChunkSize: 10}}
+
+	tests := []struct {
+		name          string
+		offset        int64
+		whence        int
+		expected      int64
+		errorExpected bool
+	}{
+		{name: "seek start", offset: 15, whence: io.SeekStart, expected: 15},
+		{name: "seek current", offset: 5, whence: io.SeekCurrent, expected: 20},
+		{name: "seek end", offset: -10, whence: io.SeekEnd, expected: 90},
+		{name: "negative offset", offset: -1, whence: io.SeekStart, expected: 90, errorExpected: true},
+		{name: "invalid whence", offset: 0, whence: 42, expected: 90, errorExpected: true},
+	}
+
+	// Cases depend on previous offset, so they are run sequentially:
+	for _, tt := range tests {
+		offset, err := file.Seek(tt.offset, tt.whence)
+		if (err != nil) != tt.errorExpected {
+			t.Errorf("%s: Seek() error = %v, errorExpected %v", tt.name, err, tt.errorExpected)
+		}
+
+		if offset != tt.expected {
+			t.Errorf("%s: Seek() = %d, want %d", tt.name, offset, tt.expected)
+		}
+	}
+
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		t.Fatalf("Seek() unexpected error: %v", err)
+	}
+
+	if _, err := file.Read(make([]byte, 1)); !errors.Is(err, io.EOF) {
+		t.Errorf("Read() error = %v, want io.EOF", err)
+	}
+}
+
+func TestFileHandlerInvalidRequests(t *testing.T) {
+	t.Parallel()
+
+	handler := NewFileHandler(nil, FileIDFromPathValue("id"))
+	mux := http.NewServeMux()
+	mux.Handle("/files/{id}", handler)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{
+			name:           "method not allowed",
+			method:         http.MethodPost,
+			path:           "/files/" + primitive.NewObjectID().Hex(),
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "invalid file ID",
+			method:         http.MethodGet,
+			path:           "/files/invalid",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if recorder.Code != tt.expectedStatus {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.expectedStatus)
+			}
+		})
+	}
+}
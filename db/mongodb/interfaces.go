@@ -33,3 +33,30 @@ type Connector interface {
 	) ([]string, error)
 	Close(ctx context.Context) error
 }
+
+// HealthReporter represents connector, which reports database health status. It is separated from Connector
+// not to break its existing implementations.
+type HealthReporter interface {
+	Healthy() bool
+}
+
+// Watcher opens change stream. Implemented by *mongo.Collection, *mongo.Database and *mongo.Client.
+type Watcher interface {
+	Watch(
+		ctx context.Context,
+		pipeline any,
+		opts ...*mongoOptions.ChangeStreamOptions,
+	) (*mongo.ChangeStream, error)
+}
+
+// ResumeTokenStore persists change stream resume tokens for continuing processing after restart.
+type ResumeTokenStore interface {
+	Load(ctx context.Context, key string) (bson.Raw, error)
+	Save(ctx context.Context, key string, token bson.Raw) error
+}
+
+// ChangeStreamConsumer asynchronously processes mongoDB change events in goroutines.
+type ChangeStreamConsumer interface {
+	Run() error
+	Stop() error
+}
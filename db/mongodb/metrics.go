@@ -0,0 +1,87 @@
+package mongodb
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	commandLabel    = "command"
+	databaseLabel   = "database"
+	collectionLabel = "collection"
+	statusLabel     = "status"
+	addressLabel    = "address"
+
+	statusOK    = "ok"
+	statusError = "error"
+)
+
+var (
+	// commandsTotal PROMQL => rate(mongodb_commands_total[30s]).
+	commandsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mongodb_commands_total",
+			Help: "Number of mongoDB commands by collection.",
+		},
+		[]string{
+			commandLabel,
+			databaseLabel,
+			collectionLabel,
+			statusLabel,
+		},
+	)
+
+	// commandDuration PROMQL => rate(mongodb_command_duration_seconds_sum[30s]) /
+	// rate(mongodb_command_duration_seconds_count[30s]).
+	commandDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "mongodb_command_duration_seconds",
+			Help: "Execution time of mongoDB command.",
+		},
+		[]string{
+			commandLabel,
+			databaseLabel,
+			collectionLabel,
+			statusLabel,
+		},
+	)
+
+	poolOpenConnections = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "mongodb_pool_open_connections",
+			Help: "Number of open connections in mongoDB connection pool.",
+		},
+		[]string{addressLabel},
+	)
+
+	poolInUseConnections = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "mongodb_pool_in_use_connections",
+			Help: "Number of connections, checked out from mongoDB connection pool.",
+		},
+		[]string{addressLabel},
+	)
+
+	poolCheckoutFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mongodb_pool_checkout_failures_total",
+			Help: "Number of failed connection checkouts from mongoDB connection pool.",
+		},
+		[]string{addressLabel},
+	)
+
+	registerMetricsOnce sync.Once
+)
+
+// registerMetrics registers mongoDB metrics in default Prometheus registry. Metrics are registered only
+// on first call, so they are not exported by applications, which do not enable them.
+func registerMetrics() {
+	registerMetricsOnce.Do(func() {
+		prometheus.MustRegister(commandsTotal)
+		prometheus.MustRegister(commandDuration)
+		prometheus.MustRegister(poolOpenConnections)
+		prometheus.MustRegister(poolInUseConnections)
+		prometheus.MustRegister(poolCheckoutFailures)
+	})
+}
@@ -0,0 +1,81 @@
+package mongodb
+
+import (
+	"time"
+)
+
+const (
+	defaultMigrationsCollection   = "migrations"
+	defaultMigrationsLockTTL      = 5 * time.Minute
+	defaultMigrationsLockWait     = 10 * time.Minute
+	defaultMigrationsLockInterval = time.Second
+)
+
+// newMigratorOptions creates *migratorOptions with default values.
+func newMigratorOptions() *migratorOptions {
+	return &migratorOptions{
+		collection:       defaultMigrationsCollection,
+		lockTTL:          defaultMigrationsLockTTL,
+		lockWaitTimeout:  defaultMigrationsLockWait,
+		lockPollInterval: defaultMigrationsLockInterval,
+	}
+}
+
+// migratorOptions represents options for Migrator configuration.
+type migratorOptions struct {
+	collection       string
+	lockTTL          time.Duration
+	lockWaitTimeout  time.Duration
+	lockPollInterval time.Duration
+}
+
+// MigratorOption represents golang functional option pattern func for Migrator configuration.
+type MigratorOption func(options *migratorOptions) error
+
+// WithMigrationsCollection sets name of collection, where applied migrations are tracked.
+// Lock document is stored in collection with "_lock" suffix.
+func WithMigrationsCollection(name string) MigratorOption {
+	return func(options *migratorOptions) error {
+		options.collection = name
+
+		return nil
+	}
+}
+
+// WithMigrationsLockTTL sets time, after which lock of crashed replica is considered stale and can be taken over.
+// Lock is prolonged in background while migrations are running. TTL should be positive.
+func WithMigrationsLockTTL(ttl time.Duration) MigratorOption {
+	return func(options *migratorOptions) error {
+		if ttl <= 0 {
+			return &InvalidMigrationError{Message: "Mongo migration error. Migrations lock TTL should be positive"}
+		}
+
+		options.lockTTL = ttl
+
+		return nil
+	}
+}
+
+// WithMigrationsLockWaitTimeout sets maximum time for waiting, while other replica holds lock.
+func WithMigrationsLockWaitTimeout(timeout time.Duration) MigratorOption {
+	return func(options *migratorOptions) error {
+		options.lockWaitTimeout = timeout
+
+		return nil
+	}
+}
+
+// WithMigrationsLockPollInterval sets interval between lock acquisition attempts. Interval should be positive.
+func WithMigrationsLockPollInterval(interval time.Duration) MigratorOption {
+	return func(options *migratorOptions) error {
+		if interval <= 0 {
+			return &InvalidMigrationError{
+				Message: "Mongo migration error. Migrations lock poll interval should be positive",
+			}
+		}
+
+		options.lockPollInterval = interval
+
+		return nil
+	}
+}
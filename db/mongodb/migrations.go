@@ -0,0 +1,469 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	mongoOptions "go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	migrationsLockSuffix = "_lock"
+	migrationsLockID     = "migrations"
+
+	indexOptionsConflictErrorCode  = 85
+	indexKeySpecsConflictErrorCode = 86
+
+	// maxIndexConflicts is a number of conflicting indexes, which can be dropped for single index: one with
+	// the same keys and one with the same name.
+	maxIndexConflicts = 2
+)
+
+// Migration is a single versioned change of database, such as index creation, JSON schema validator
+// setup or data backfill. Migrations are applied in ascending order of versions.
+type Migration struct {
+	Version     uint64
+	Description string
+	Up          func(ctx context.Context, database *mongo.Database) error
+}
+
+// AppliedMigration is a record about migration, which was already applied to database.
+type AppliedMigration struct {
+	Version     uint64    `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"appliedAt"`
+}
+
+// migrationsLock is a lock document, which prevents concurrent migration runs from several replicas.
+type migrationsLock struct {
+	ID        string    `bson:"_id"`
+	Owner     string    `bson:"owner"`
+	ExpiresAt time.Time `bson:"expiresAt"`
+}
+
+// Migrator applies versioned migrations to mongoDB and tracks applied ones in migrations collection.
+type Migrator struct {
+	database   *mongo.Database
+	migrations []Migration
+	owner      string
+	options    *migratorOptions
+}
+
+// NewMigrator creates *Migrator for provided database. Migrations are validated and sorted by version.
+func NewMigrator(
+	connector Connector,
+	database string,
+	migrations []Migration,
+	opts ...MigratorOption,
+) (*Migrator, error) {
+	options := newMigratorOptions()
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, err
+		}
+	}
+
+	sorted, err := sortMigrations(migrations)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := connector.Database(database)
+	if err != nil {
+		return nil, err
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	return &Migrator{
+		database:   db,
+		migrations: sorted,
+		owner:      fmt.Sprintf("%s-%s", hostname, uuid.New().String()),
+		options:    options,
+	}, nil
+}
+
+// sortMigrations validates migrations and returns their copy, sorted by version.
+func sortMigrations(migrations []Migration) ([]Migration, error) {
+	sorted := slices.Clone(migrations)
+	slices.SortFunc(sorted, func(a, b Migration) int {
+		switch {
+		case a.Version < b.Version:
+			return -1
+		case a.Version > b.Version:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	for i, migration := range sorted {
+		if migration.Version == 0 {
+			return nil, &InvalidMigrationError{Message: "Migration version should be positive"}
+		}
+
+		if migration.Up == nil {
+			return nil, &InvalidMigrationError{
+				Message: fmt.Sprintf("Migration %d has no Up function", migration.Version),
+			}
+		}
+
+		if i > 0 && sorted[i-1].Version == migration.Version {
+			return nil, &InvalidMigrationError{
+				Message: fmt.Sprintf("Migration version %d is duplicated", migration.Version),
+			}
+		}
+	}
+
+	return sorted, nil
+}
+
+// Up applies all not yet applied migrations in ascending order of versions under migrations lock.
+// Returns versions of migrations, which were applied during this run. If lock can not be prolonged,
+// migrations are canceled, because lock can be taken over by another replica.
+func (m *Migrator) Up(ctx context.Context) (versions []uint64, err error) {
+	if err = m.lock(ctx); err != nil {
+		return nil, err
+	}
+
+	migrationCtx, cancelMigration := context.WithCancelCause(ctx)
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		m.prolongLock(migrationCtx, cancelMigration)
+	}()
+
+	defer func() {
+		cancelMigration(nil)
+		wg.Wait()
+
+		// Releasing lock even if ctx was canceled not to block other replicas until lock expires:
+		err = errors.Join(err, m.unlock(context.WithoutCancel(ctx)))
+	}()
+
+	versions, err = m.apply(migrationCtx)
+	if err != nil && ctx.Err() == nil {
+		// Migration was canceled due to failed lock prolongation:
+		if cause := context.Cause(migrationCtx); cause != nil {
+			err = errors.Join(err, cause)
+		}
+	}
+
+	return versions, err
+}
+
+// apply applies not yet applied migrations and returns their versions.
+func (m *Migrator) apply(ctx context.Context) ([]uint64, error) {
+	applied, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	appliedVersions := make(map[uint64]struct{}, len(applied))
+	for _, migration := range applied {
+		appliedVersions[migration.Version] = struct{}{}
+	}
+
+	var versions []uint64
+
+	for _, migration := range m.migrations {
+		if _, ok := appliedVersions[migration.Version]; ok {
+			continue
+		}
+
+		if err = migration.Up(ctx, m.database); err != nil {
+			return versions, fmt.Errorf("migration %d failed: %w", migration.Version, err)
+		}
+
+		_, err = m.database.Collection(m.options.collection).InsertOne(
+			ctx,
+			AppliedMigration{
+				Version:     migration.Version,
+				Description: migration.Description,
+				AppliedAt:   time.Now().UTC(),
+			},
+		)
+		if err != nil {
+			return versions, err
+		}
+
+		versions = append(versions, migration.Version)
+	}
+
+	return versions, nil
+}
+
+// Applied returns migrations, which were already applied to database, in ascending order of versions.
+func (m *Migrator) Applied(ctx context.Context) ([]AppliedMigration, error) {
+	cursor, err := m.database.Collection(m.options.collection).Find(
+		ctx,
+		bson.D{},
+		mongoOptions.Find().SetSort(bson.D{{Key: idField, Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make([]AppliedMigration, 0)
+	if err = cursor.All(ctx, &applied); err != nil {
+		return nil, err
+	}
+
+	return applied, nil
+}
+
+// lockCollection returns collection, where lock document is stored.
+func (m *Migrator) lockCollection() *mongo.Collection {
+	return m.database.Collection(m.options.collection + migrationsLockSuffix)
+}
+
+// lock acquires migrations lock, waiting while it is held by another replica.
+func (m *Migrator) lock(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, m.options.lockWaitTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(m.options.lockPollInterval)
+	defer ticker.Stop()
+
+	for {
+		acquired, err := m.tryLock(ctx)
+		if err != nil {
+			return err
+		}
+
+		if acquired {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return &MigrationLockError{BaseErr: ctx.Err()}
+		case <-ticker.C:
+		}
+	}
+}
+
+// tryLock tries to create lock document or to take over expired lock of another replica.
+func (m *Migrator) tryLock(ctx context.Context) (bool, error) {
+	now := time.Now().UTC()
+	lock := migrationsLock{
+		ID:        migrationsLockID,
+		Owner:     m.owner,
+		ExpiresAt: now.Add(m.options.lockTTL),
+	}
+
+	_, err := m.lockCollection().InsertOne(ctx, lock)
+	if err == nil {
+		return true, nil
+	}
+
+	if !mongo.IsDuplicateKeyError(err) {
+		return false, err
+	}
+
+	result, err := m.lockCollection().UpdateOne(
+		ctx,
+		bson.D{
+			{Key: idField, Value: migrationsLockID},
+			{Key: "expiresAt", Value: bson.D{{Key: "$lt", Value: now}}},
+		},
+		bson.D{
+			{Key: "$set", Value: bson.D{
+				{Key: "owner", Value: lock.Owner},
+				{Key: "expiresAt", Value: lock.ExpiresAt},
+			}},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+// prolongLock periodically extends lock expiration until ctx is done. If lock can not be prolonged,
+// ctx is canceled with *MigrationLockError as a cause.
+func (m *Migrator) prolongLock(ctx context.Context, cancel context.CancelCauseFunc) {
+	ticker := time.NewTicker(max(m.options.lockTTL/3, time.Nanosecond))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := m.lockCollection().UpdateOne(
+				ctx,
+				bson.D{
+					{Key: idField, Value: migrationsLockID},
+					{Key: "owner", Value: m.owner},
+				},
+				bson.D{
+					{Key: "$set", Value: bson.D{
+						{Key: "expiresAt", Value: time.Now().UTC().Add(m.options.lockTTL)},
+					}},
+				},
+			)
+
+			switch {
+			case ctx.Err() != nil:
+				return
+			case err != nil:
+				cancel(&MigrationLockError{Message: "Mongo migration error. Failed to prolong migrations lock", BaseErr: err})
+
+				return
+			case result.MatchedCount == 0:
+				cancel(&MigrationLockError{Message: "Mongo migration error. Migrations lock was lost"})
+
+				return
+			}
+		}
+	}
+}
+
+// unlock releases migrations lock, if it is held by this Migrator.
+func (m *Migrator) unlock(ctx context.Context) error {
+	result, err := m.lockCollection().DeleteOne(
+		ctx,
+		bson.D{
+			{Key: idField, Value: migrationsLockID},
+			{Key: "owner", Value: m.owner},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return &MigrationLockError{Message: "Mongo migration error. Migrations lock was lost"}
+	}
+
+	return nil
+}
+
+// EnsureIndexes declaratively creates provided indexes for collection. Indexes, which already exist
+// with the same specification, are left untouched, so EnsureIndexes is safe to call on every startup.
+// Existing indexes with the same keys or name, but with different specification, are dropped and created
+// again with provided specification. Returns names of ensured indexes.
+func EnsureIndexes(
+	ctx context.Context,
+	collection *mongo.Collection,
+	indexes ...mongo.IndexModel,
+) ([]string, error) {
+	if collection == nil {
+		return nil, &NilCollectionError{}
+	}
+
+	if len(indexes) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		name, err := ensureIndex(ctx, collection.Indexes(), index)
+		if err != nil {
+			return names, err
+		}
+
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// ensureIndex creates index, dropping existing indexes, which conflict with it, before.
+func ensureIndex(ctx context.Context, indexes mongo.IndexView, index mongo.IndexModel) (string, error) {
+	for conflicts := 0; ; conflicts++ {
+		name, err := indexes.CreateOne(ctx, index)
+
+		code := indexConflictCode(err)
+		if code == 0 || conflicts == maxIndexConflicts {
+			return name, err
+		}
+
+		// Index with the same keys, but different options, exists:
+		if code == indexOptionsConflictErrorCode {
+			if _, dropErr := indexes.DropOneWithKey(ctx, index.Keys); dropErr != nil {
+				return "", errors.Join(err, dropErr)
+			}
+
+			continue
+		}
+
+		// Index with the same name, but different keys, exists:
+		if index.Options == nil || index.Options.Name == nil {
+			return "", err
+		}
+
+		if _, dropErr := indexes.DropOne(ctx, *index.Options.Name); dropErr != nil {
+			return "", errors.Join(err, dropErr)
+		}
+	}
+}
+
+// indexConflictCode returns code of error, if index was not created due to conflict with existing index,
+// and 0 otherwise.
+func indexConflictCode(err error) int32 {
+	var commandErr mongo.CommandError
+	if !errors.As(err, &commandErr) {
+		return 0
+	}
+
+	if commandErr.Code == indexOptionsConflictErrorCode || commandErr.Code == indexKeySpecsConflictErrorCode {
+		return commandErr.Code
+	}
+
+	return 0
+}
+
+// EnsureJSONSchema sets JSON schema validator for collection, creating collection, if it does not exist.
+func EnsureJSONSchema(
+	ctx context.Context,
+	database *mongo.Database,
+	collection string,
+	schema any,
+) error {
+	if database == nil {
+		return &NilDatabaseError{}
+	}
+
+	validator := bson.D{{Key: "$jsonSchema", Value: schema}}
+
+	err := database.CreateCollection(
+		ctx,
+		collection,
+		mongoOptions.CreateCollection().SetValidator(validator),
+	)
+	if err == nil {
+		return nil
+	}
+
+	var commandErr mongo.CommandError
+	if !errors.As(err, &commandErr) || commandErr.Name != "NamespaceExists" {
+		return err
+	}
+
+	return database.RunCommand(
+		ctx,
+		bson.D{
+			{Key: "collMod", Value: collection},
+			{Key: "validator", Value: validator},
+		},
+	).Err()
+}
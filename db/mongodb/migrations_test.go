@@ -0,0 +1,196 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func noopMigration(context.Context, *mongo.Database) error {
+	return nil
+}
+
+func TestSortMigrations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		migrations       []Migration
+		expectedVersions []uint64
+		errorExpected    bool
+	}{
+		{
+			name:             "no migrations",
+			migrations:       nil,
+			expectedVersions: []uint64{},
+		},
+		{
+			name: "unordered migrations",
+			migrations: []Migration{
+				{Version: 3, Up: noopMigration},
+				{Version: 1, Up: noopMigration},
+				{Version: 2, Up: noopMigration},
+			},
+			expectedVersions: []uint64{1, 2, 3},
+		},
+		{
+			name:          "zero version",
+			migrations:    []Migration{{Version: 0, Up: noopMigration}},
+			errorExpected: true,
+		},
+		{
+			name:          "nil up function",
+			migrations:    []Migration{{Version: 1}},
+			errorExpected: true,
+		},
+		{
+			name: "duplicated version",
+			migrations: []Migration{
+				{Version: 1, Up: noopMigration},
+				{Version: 1, Up: noopMigration},
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sorted, err := sortMigrations(tt.migrations)
+			if tt.errorExpected {
+				var invalidMigrationErr *InvalidMigrationError
+				if !errors.As(err, &invalidMigrationErr) {
+					t.Fatalf("sortMigrations() error = %v, want *InvalidMigrationError", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("sortMigrations() unexpected error: %v", err)
+			}
+
+			versions := make([]uint64, 0, len(sorted))
+			for _, migration := range sorted {
+				versions = append(versions, migration.Version)
+			}
+
+			if !slices.Equal(versions, tt.expectedVersions) {
+				t.Errorf("versions = %v, want %v", versions, tt.expectedVersions)
+			}
+		})
+	}
+}
+
+func TestMigratorOptionFunctions(t *testing.T) {
+	t.Parallel()
+
+	opts := newMigratorOptions()
+	if opts.collection != defaultMigrationsCollection {
+		t.Errorf("collection = %q, want %q", opts.collection, defaultMigrationsCollection)
+	}
+
+	for _, option := range []MigratorOption{
+		WithMigrationsCollection("schema_migrations"),
+		WithMigrationsLockTTL(time.Minute),
+		WithMigrationsLockWaitTimeout(time.Hour),
+		WithMigrationsLockPollInterval(time.Millisecond),
+	} {
+		if err := option(opts); err != nil {
+			t.Fatalf("option returned error: %v", err)
+		}
+	}
+
+	if opts.collection != "schema_migrations" {
+		t.Errorf("collection = %q, want schema_migrations", opts.collection)
+	}
+
+	if opts.lockTTL != time.Minute {
+		t.Errorf("lockTTL = %v, want 1m", opts.lockTTL)
+	}
+
+	if opts.lockWaitTimeout != time.Hour {
+		t.Errorf("lockWaitTimeout = %v, want 1h", opts.lockWaitTimeout)
+	}
+
+	if opts.lockPollInterval != time.Millisecond {
+		t.Errorf("lockPollInterval = %v, want 1ms", opts.lockPollInterval)
+	}
+}
+
+func TestMigratorOptionValidation(t *testing.T) {
+	t.Parallel()
+
+	for _, option := range []MigratorOption{
+		WithMigrationsLockTTL(0),
+		WithMigrationsLockTTL(-time.Second),
+		WithMigrationsLockPollInterval(0),
+	} {
+		var invalidMigrationErr *InvalidMigrationError
+		if err := option(newMigratorOptions()); !errors.As(err, &invalidMigrationErr) {
+			t.Errorf("option error = %v, want *InvalidMigrationError", err)
+		}
+	}
+}
+
+func TestIndexConflictCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected int32
+	}{
+		{name: "no error", err: nil, expected: 0},
+		{name: "other error", err: errors.New("network error"), expected: 0},
+		{name: "other command error", err: mongo.CommandError{Code: 11000}, expected: 0},
+		{
+			name:     "options conflict",
+			err:      mongo.CommandError{Code: indexOptionsConflictErrorCode},
+			expected: indexOptionsConflictErrorCode,
+		},
+		{
+			name:     "wrapped key specs conflict",
+			err:      fmt.Errorf("create index: %w", mongo.CommandError{Code: indexKeySpecsConflictErrorCode}),
+			expected: indexKeySpecsConflictErrorCode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if code := indexConflictCode(tt.err); code != tt.expected {
+				t.Errorf("indexConflictCode() = %d, want %d", code, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEnsureIndexesNilCollection(t *testing.T) {
+	t.Parallel()
+
+	_, err := EnsureIndexes(context.Background(), nil, mongo.IndexModel{})
+
+	var nilCollectionErr *NilCollectionError
+	if !errors.As(err, &nilCollectionErr) {
+		t.Errorf("EnsureIndexes() error = %v, want *NilCollectionError", err)
+	}
+}
+
+func TestEnsureJSONSchemaNilDatabase(t *testing.T) {
+	t.Parallel()
+
+	err := EnsureJSONSchema(context.Background(), nil, "users", nil)
+
+	var nilDatabaseErr *NilDatabaseError
+	if !errors.As(err, &nilDatabaseErr) {
+		t.Errorf("EnsureJSONSchema() error = %v, want *NilDatabaseError", err)
+	}
+}
@@ -146,3 +146,190 @@ func (mr *MockConnectorMockRecorder) Ping(ctx, rp any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockConnector)(nil).Ping), ctx, rp)
 }
+
+// MockHealthReporter is a mock of HealthReporter interface.
+type MockHealthReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthReporterMockRecorder
+	isgomock struct{}
+}
+
+// MockHealthReporterMockRecorder is the mock recorder for MockHealthReporter.
+type MockHealthReporterMockRecorder struct {
+	mock *MockHealthReporter
+}
+
+// NewMockHealthReporter creates a new mock instance.
+func NewMockHealthReporter(ctrl *gomock.Controller) *MockHealthReporter {
+	mock := &MockHealthReporter{ctrl: ctrl}
+	mock.recorder = &MockHealthReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthReporter) EXPECT() *MockHealthReporterMockRecorder {
+	return m.recorder
+}
+
+// Healthy mocks base method.
+func (m *MockHealthReporter) Healthy() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Healthy")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Healthy indicates an expected call of Healthy.
+func (mr *MockHealthReporterMockRecorder) Healthy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Healthy", reflect.TypeOf((*MockHealthReporter)(nil).Healthy))
+}
+
+// MockWatcher is a mock of Watcher interface.
+type MockWatcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockWatcherMockRecorder
+	isgomock struct{}
+}
+
+// MockWatcherMockRecorder is the mock recorder for MockWatcher.
+type MockWatcherMockRecorder struct {
+	mock *MockWatcher
+}
+
+// NewMockWatcher creates a new mock instance.
+func NewMockWatcher(ctrl *gomock.Controller) *MockWatcher {
+	mock := &MockWatcher{ctrl: ctrl}
+	mock.recorder = &MockWatcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWatcher) EXPECT() *MockWatcherMockRecorder {
+	return m.recorder
+}
+
+// Watch mocks base method.
+func (m *MockWatcher) Watch(ctx context.Context, pipeline any, opts ...*options.ChangeStreamOptions) (*mongo.ChangeStream, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, pipeline}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Watch", varargs...)
+	ret0, _ := ret[0].(*mongo.ChangeStream)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockWatcherMockRecorder) Watch(ctx, pipeline any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, pipeline}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockWatcher)(nil).Watch), varargs...)
+}
+
+// MockResumeTokenStore is a mock of ResumeTokenStore interface.
+type MockResumeTokenStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockResumeTokenStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockResumeTokenStoreMockRecorder is the mock recorder for MockResumeTokenStore.
+type MockResumeTokenStoreMockRecorder struct {
+	mock *MockResumeTokenStore
+}
+
+// NewMockResumeTokenStore creates a new mock instance.
+func NewMockResumeTokenStore(ctrl *gomock.Controller) *MockResumeTokenStore {
+	mock := &MockResumeTokenStore{ctrl: ctrl}
+	mock.recorder = &MockResumeTokenStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResumeTokenStore) EXPECT() *MockResumeTokenStoreMockRecorder {
+	return m.recorder
+}
+
+// Load mocks base method.
+func (m *MockResumeTokenStore) Load(ctx context.Context, key string) (bson.Raw, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Load", ctx, key)
+	ret0, _ := ret[0].(bson.Raw)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Load indicates an expected call of Load.
+func (mr *MockResumeTokenStoreMockRecorder) Load(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockResumeTokenStore)(nil).Load), ctx, key)
+}
+
+// Save mocks base method.
+func (m *MockResumeTokenStore) Save(ctx context.Context, key string, token bson.Raw) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, key, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockResumeTokenStoreMockRecorder) Save(ctx, key, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockResumeTokenStore)(nil).Save), ctx, key, token)
+}
+
+// MockChangeStreamConsumer is a mock of ChangeStreamConsumer interface.
+type MockChangeStreamConsumer struct {
+	ctrl     *gomock.Controller
+	recorder *MockChangeStreamConsumerMockRecorder
+	isgomock struct{}
+}
+
+// MockChangeStreamConsumerMockRecorder is the mock recorder for MockChangeStreamConsumer.
+type MockChangeStreamConsumerMockRecorder struct {
+	mock *MockChangeStreamConsumer
+}
+
+// NewMockChangeStreamConsumer creates a new mock instance.
+func NewMockChangeStreamConsumer(ctrl *gomock.Controller) *MockChangeStreamConsumer {
+	mock := &MockChangeStreamConsumer{ctrl: ctrl}
+	mock.recorder = &MockChangeStreamConsumerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChangeStreamConsumer) EXPECT() *MockChangeStreamConsumerMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockChangeStreamConsumer) Run() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockChangeStreamConsumerMockRecorder) Run() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockChangeStreamConsumer)(nil).Run))
+}
+
+// Stop mocks base method.
+func (m *MockChangeStreamConsumer) Stop() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockChangeStreamConsumerMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockChangeStreamConsumer)(nil).Stop))
+}
@@ -0,0 +1,279 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+	"github.com/DKhorkov/libs/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const redactedValue = "?"
+
+// commandMetaFields are fields, which driver adds to every command and which are not useful in statement.
+var commandMetaFields = map[string]struct{}{
+	"lsid":             {},
+	"$db":              {},
+	"$clusterTime":     {},
+	"$readPreference":  {},
+	"txnNumber":        {},
+	"autocommit":       {},
+	"startTransaction": {},
+}
+
+// startedCommand stores information about started command until it is finished.
+type startedCommand struct {
+	database   string
+	collection string
+	statement  string
+	span       trace.Span
+}
+
+// commandObserver records metrics, spans and slow command logs for mongoDB commands.
+type commandObserver struct {
+	metricsEnabled       bool
+	traceProvider        tracing.Provider
+	logger               logging.Logger
+	slowCommandThreshold time.Duration
+	commands             sync.Map
+}
+
+// newCommandObserver creates *commandObserver on base of connector options or returns nil,
+// if monitoring is not enabled.
+func newCommandObserver(connectOpts options) *commandObserver {
+	if !connectOpts.metricsEnabled && connectOpts.traceProvider == nil && connectOpts.slowCommandThreshold <= 0 {
+		return nil
+	}
+
+	if connectOpts.metricsEnabled {
+		registerMetrics()
+	}
+
+	return &commandObserver{
+		metricsEnabled:       connectOpts.metricsEnabled,
+		traceProvider:        connectOpts.traceProvider,
+		logger:               connectOpts.slowCommandLogger,
+		slowCommandThreshold: connectOpts.slowCommandThreshold,
+	}
+}
+
+// commandMonitor returns *event.CommandMonitor, which passes command events to commandObserver.
+func (o *commandObserver) commandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: o.started,
+		Succeeded: func(ctx context.Context, succeededEvent *event.CommandSucceededEvent) {
+			o.finished(ctx, &succeededEvent.CommandFinishedEvent, "")
+		},
+		Failed: func(ctx context.Context, failedEvent *event.CommandFailedEvent) {
+			o.finished(ctx, &failedEvent.CommandFinishedEvent, failedEvent.Failure)
+		},
+	}
+}
+
+// poolMonitor returns *event.PoolMonitor, which records connection pool metrics,
+// or nil, if metrics are not enabled.
+func (o *commandObserver) poolMonitor() *event.PoolMonitor {
+	if !o.metricsEnabled {
+		return nil
+	}
+
+	return &event.PoolMonitor{
+		Event: func(poolEvent *event.PoolEvent) {
+			labels := prometheus.Labels{addressLabel: poolEvent.Address}
+
+			switch poolEvent.Type {
+			case event.ConnectionCreated:
+				poolOpenConnections.With(labels).Inc()
+			case event.ConnectionClosed:
+				poolOpenConnections.With(labels).Dec()
+			case event.GetSucceeded:
+				poolInUseConnections.With(labels).Inc()
+			case event.ConnectionReturned:
+				poolInUseConnections.With(labels).Dec()
+			case event.GetFailed:
+				poolCheckoutFailures.With(labels).Inc()
+			}
+		},
+	}
+}
+
+// started stores started command and creates span for it.
+func (o *commandObserver) started(ctx context.Context, startedEvent *event.CommandStartedEvent) {
+	command := &startedCommand{
+		database:   startedEvent.DatabaseName,
+		collection: commandCollection(startedEvent.Command, startedEvent.CommandName),
+	}
+
+	if o.traceProvider != nil || o.slowCommandThreshold > 0 {
+		command.statement = redactCommand(startedEvent.Command)
+	}
+
+	if o.traceProvider != nil {
+		_, command.span = o.traceProvider.Span(
+			ctx,
+			"mongodb."+startedEvent.CommandName,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", "mongodb"),
+				attribute.String("db.name", command.database),
+				attribute.String("db.operation", startedEvent.CommandName),
+				attribute.String("db.mongodb.collection", command.collection),
+				attribute.String("db.statement", command.statement),
+			),
+		)
+	}
+
+	o.commands.Store(commandKey(startedEvent.ConnectionID, startedEvent.RequestID), command)
+}
+
+// finished records metrics, ends span and logs command, if it was slow.
+func (o *commandObserver) finished(ctx context.Context, finishedEvent *event.CommandFinishedEvent, failure string) {
+	value, ok := o.commands.LoadAndDelete(commandKey(finishedEvent.ConnectionID, finishedEvent.RequestID))
+	if !ok {
+		return
+	}
+
+	command, ok := value.(*startedCommand)
+	if !ok {
+		return
+	}
+
+	status := statusOK
+	if failure != "" {
+		status = statusError
+	}
+
+	if o.metricsEnabled {
+		labels := prometheus.Labels{
+			commandLabel:    finishedEvent.CommandName,
+			databaseLabel:   command.database,
+			collectionLabel: command.collection,
+			statusLabel:     status,
+		}
+
+		commandsTotal.With(labels).Inc()
+		commandDuration.With(labels).Observe(finishedEvent.Duration.Seconds())
+	}
+
+	if command.span != nil {
+		if failure != "" {
+			command.span.SetStatus(codes.Error, failure)
+		}
+
+		command.span.End()
+	}
+
+	if o.logger != nil && o.slowCommandThreshold > 0 && finishedEvent.Duration >= o.slowCommandThreshold {
+		o.logger.WarnContext(
+			ctx,
+			"Slow command detected",
+			"Command",
+			finishedEvent.CommandName,
+			"Database",
+			command.database,
+			"Collection",
+			command.collection,
+			"Statement",
+			command.statement,
+			"Duration",
+			finishedEvent.Duration.String(),
+			"Threshold",
+			o.slowCommandThreshold.String(),
+		)
+	}
+}
+
+// commandKey builds key for started command. Request IDs are unique only within connection.
+func commandKey(connectionID string, requestID int64) string {
+	return fmt.Sprintf("%s/%d", connectionID, requestID)
+}
+
+// commandCollection returns collection name, which is stored as a value of command name field
+// for collection level commands, such as find or insert.
+func commandCollection(command bson.Raw, commandName string) string {
+	value, err := command.LookupErr(commandName)
+	if err != nil {
+		return ""
+	}
+
+	collection, ok := value.StringValueOK()
+	if !ok {
+		return ""
+	}
+
+	return collection
+}
+
+// redactCommand converts command to extended JSON, where all literal values are replaced with "?"
+// not to store sensitive data in traces and logs. Command name and collection are kept as is.
+func redactCommand(command bson.Raw) string {
+	elements, err := command.Elements()
+	if err != nil {
+		return ""
+	}
+
+	redacted := make(bson.D, 0, len(elements))
+	for i, element := range elements {
+		key := element.Key()
+		if _, ok := commandMetaFields[key]; ok {
+			continue
+		}
+
+		// The first element is a command name with collection name as a value:
+		if i == 0 {
+			redacted = append(redacted, bson.E{Key: key, Value: element.Value()})
+
+			continue
+		}
+
+		redacted = append(redacted, bson.E{Key: key, Value: redactValue(element.Value())})
+	}
+
+	statement, err := bson.MarshalExtJSON(redacted, false, false)
+	if err != nil {
+		return ""
+	}
+
+	return string(statement)
+}
+
+// redactValue recursively replaces literal values of documents and arrays with "?".
+func redactValue(value bson.RawValue) any {
+	switch value.Type {
+	case bsontype.EmbeddedDocument:
+		elements, err := value.Document().Elements()
+		if err != nil {
+			return redactedValue
+		}
+
+		redacted := make(bson.D, 0, len(elements))
+		for _, element := range elements {
+			redacted = append(redacted, bson.E{Key: element.Key(), Value: redactValue(element.Value())})
+		}
+
+		return redacted
+	case bsontype.Array:
+		values, err := value.Array().Values()
+		if err != nil {
+			return redactedValue
+		}
+
+		redacted := make(bson.A, 0, len(values))
+		for _, arrayValue := range values {
+			redacted = append(redacted, redactValue(arrayValue))
+		}
+
+		return redacted
+	default:
+		return redactedValue
+	}
+}
@@ -0,0 +1,171 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	loggermock "github.com/DKhorkov/libs/logging/mocks"
+	mocktracing "github.com/DKhorkov/libs/tracing/mocks"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.uber.org/mock/gomock"
+)
+
+func mustMarshal(t *testing.T, document bson.D) bson.Raw {
+	t.Helper()
+
+	raw, err := bson.Marshal(document)
+	if err != nil {
+		t.Fatalf("bson.Marshal() unexpected error: %v", err)
+	}
+
+	return raw
+}
+
+func TestRedactCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		command  bson.D
+		expected string
+	}{
+		{
+			name: "find with filter",
+			command: bson.D{
+				{Key: "find", Value: "users"},
+				{Key: "filter", Value: bson.D{
+					{Key: "email", Value: "user@example.com"},
+					{Key: "age", Value: bson.D{{Key: "$gt", Value: 18}}},
+				}},
+				{Key: "limit", Value: 1},
+				{Key: "lsid", Value: bson.D{{Key: "id", Value: "session"}}},
+				{Key: "$db", Value: "app"},
+			},
+			expected: `{"find":"users","filter":{"email":"?","age":{"$gt":"?"}},"limit":"?"}`,
+		},
+		{
+			name: "insert with documents",
+			command: bson.D{
+				{Key: "insert", Value: "users"},
+				{Key: "documents", Value: bson.A{
+					bson.D{{Key: "password", Value: "secret"}},
+				}},
+			},
+			expected: `{"insert":"users","documents":[{"password":"?"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if actual := redactCommand(mustMarshal(t, tt.command)); actual != tt.expected {
+				t.Errorf("redactCommand() = %s, want %s", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCommandCollection(t *testing.T) {
+	t.Parallel()
+
+	command := mustMarshal(t, bson.D{{Key: "find", Value: "users"}})
+	if collection := commandCollection(command, "find"); collection != "users" {
+		t.Errorf("commandCollection() = %q, want users", collection)
+	}
+
+	command = mustMarshal(t, bson.D{{Key: "ping", Value: 1}})
+	if collection := commandCollection(command, "ping"); collection != "" {
+		t.Errorf("commandCollection() = %q, want empty string", collection)
+	}
+}
+
+func TestNewCommandObserverDisabled(t *testing.T) {
+	t.Parallel()
+
+	if observer := newCommandObserver(options{}); observer != nil {
+		t.Errorf("newCommandObserver() = %v, want nil", observer)
+	}
+}
+
+func TestCommandObserver(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	traceProvider := mocktracing.NewMockProvider(ctrl)
+	logger := loggermock.NewMockLogger(ctrl)
+
+	observer := newCommandObserver(
+		options{
+			metricsEnabled:       true,
+			traceProvider:        traceProvider,
+			slowCommandLogger:    logger,
+			slowCommandThreshold: time.Second,
+		},
+	)
+
+	if observer.poolMonitor() == nil {
+		t.Error("poolMonitor() = nil, want monitor")
+	}
+
+	monitor := observer.commandMonitor()
+	ctx := context.Background()
+	command := mustMarshal(t, bson.D{
+		{Key: "find", Value: "users"},
+		{Key: "filter", Value: bson.D{{Key: "email", Value: "user@example.com"}}},
+	})
+
+	traceProvider.
+		EXPECT().
+		Span(gomock.Any(), "mongodb.find", gomock.Any()).
+		Return(ctx, mocktracing.NewMockSpan()).
+		Times(2)
+
+	logger.
+		EXPECT().
+		WarnContext(gomock.Any(), "Slow command detected", gomock.Any()).
+		Times(1)
+
+	// Fast command is not logged:
+	monitor.Started(ctx, &event.CommandStartedEvent{
+		Command:      command,
+		DatabaseName: "app",
+		CommandName:  "find",
+		RequestID:    1,
+		ConnectionID: "localhost:27017[-1]",
+	})
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{
+			CommandName:  "find",
+			RequestID:    1,
+			ConnectionID: "localhost:27017[-1]",
+			Duration:     time.Millisecond,
+		},
+	})
+
+	// Slow failed command is logged:
+	monitor.Started(ctx, &event.CommandStartedEvent{
+		Command:      command,
+		DatabaseName: "app",
+		CommandName:  "find",
+		RequestID:    2,
+		ConnectionID: "localhost:27017[-1]",
+	})
+	monitor.Failed(ctx, &event.CommandFailedEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{
+			CommandName:  "find",
+			RequestID:    2,
+			ConnectionID: "localhost:27017[-1]",
+			Duration:     2 * time.Second,
+		},
+		Failure: "timeout",
+	})
+
+	observer.commands.Range(func(key, _ any) bool {
+		t.Errorf("command %v was not removed after finish", key)
+
+		return true
+	})
+}
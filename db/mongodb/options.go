@@ -1,7 +1,18 @@
 package mongodb
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
 	"time"
+
+	"github.com/DKhorkov/libs/db/health"
+	"github.com/DKhorkov/libs/logging"
+	"github.com/DKhorkov/libs/tracing"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
 // options represents options for *mongo.Client configuration.
@@ -14,6 +25,24 @@ type options struct {
 	minPoolSize              uint64
 	maxConnectionTimeout     time.Duration
 	maxConnectionIdleTimeout time.Duration
+	retry                    health.RetryConfig
+	healthCheckInterval      time.Duration
+	healthCheckTimeout       time.Duration
+	healthCheckCallback      func(healthy bool)
+	hosts                    []string
+	replicaSet               string
+	authMechanism            string
+	appName                  string
+	compressors              []string
+	retryWrites              *bool
+	tlsConfig                *tls.Config
+	readPreference           *readpref.ReadPref
+	readConcern              *readconcern.ReadConcern
+	writeConcern             *writeconcern.WriteConcern
+	metricsEnabled           bool
+	traceProvider            tracing.Provider
+	slowCommandLogger        logging.Logger
+	slowCommandThreshold     time.Duration
 }
 
 // Option represents golang functional option pattern func for mongo.Client configuration.
@@ -90,3 +119,212 @@ func WithMaxConnectionIdleTime(timeout time.Duration) Option {
 		return nil
 	}
 }
+
+// WithConnectionRetryDeadline sets total time for waiting mongoDB startup, during which ping attempts
+// are retried with backoff. By default, ping is attempted only once.
+func WithConnectionRetryDeadline(deadline time.Duration) Option {
+	return func(options *options) error {
+		options.retry.Deadline = deadline
+
+		return nil
+	}
+}
+
+// WithConnectionRetryBackoff sets initial and maximum delays between ping attempts.
+func WithConnectionRetryBackoff(initial, maxBackoff time.Duration) Option {
+	return func(options *options) error {
+		options.retry.InitialBackoff = initial
+		options.retry.MaxBackoff = maxBackoff
+
+		return nil
+	}
+}
+
+// WithHealthCheckInterval enables background health checks of mongoDB with provided interval.
+func WithHealthCheckInterval(interval time.Duration) Option {
+	return func(options *options) error {
+		options.healthCheckInterval = interval
+
+		return nil
+	}
+}
+
+// WithHealthCheckTimeout sets timeout for single health check. By default, health check interval is used.
+func WithHealthCheckTimeout(timeout time.Duration) Option {
+	return func(options *options) error {
+		options.healthCheckTimeout = timeout
+
+		return nil
+	}
+}
+
+// WithHealthCheckCallback sets callback, which is called every time mongoDB health status changes.
+// Can be used for readiness probes.
+func WithHealthCheckCallback(callback func(healthy bool)) Option {
+	return func(options *options) error {
+		options.healthCheckCallback = callback
+
+		return nil
+	}
+}
+
+// WithHosts sets list of "host:port" seeds, which overrides hosts from DSN.
+func WithHosts(hosts ...string) Option {
+	return func(options *options) error {
+		options.hosts = append(options.hosts, hosts...)
+
+		return nil
+	}
+}
+
+// WithReplicaSet sets name of replica set to connect to.
+func WithReplicaSet(name string) Option {
+	return func(options *options) error {
+		options.replicaSet = name
+
+		return nil
+	}
+}
+
+// WithAuthMechanism sets authentication mechanism, such as "SCRAM-SHA-256" or "MONGODB-X509".
+func WithAuthMechanism(mechanism string) Option {
+	return func(options *options) error {
+		options.authMechanism = mechanism
+
+		return nil
+	}
+}
+
+// WithAppName sets application name, which is shown in mongoDB logs and profiler.
+func WithAppName(name string) Option {
+	return func(options *options) error {
+		options.appName = name
+
+		return nil
+	}
+}
+
+// WithCompressors sets compressors for communication with server, such as "zstd", "zlib" or "snappy".
+func WithCompressors(compressors ...string) Option {
+	return func(options *options) error {
+		options.compressors = append(options.compressors, compressors...)
+
+		return nil
+	}
+}
+
+// WithRetryWrites enables or disables retryable writes.
+func WithRetryWrites(retryWrites bool) Option {
+	return func(options *options) error {
+		options.retryWrites = &retryWrites
+
+		return nil
+	}
+}
+
+// WithTLSConfig sets TLS configuration for connection with mongoDB.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(options *options) error {
+		options.tlsConfig = config
+
+		return nil
+	}
+}
+
+// WithTLSFiles enables TLS with CA certificate and client certificate from provided PEM files.
+// Client certificate file should contain both certificate and private key. Any of files can be empty.
+func WithTLSFiles(caFile, certificateKeyFile string) Option {
+	return func(options *options) error {
+		config := &tls.Config{MinVersion: tls.VersionTLS12}
+
+		if caFile != "" {
+			caPEM, err := os.ReadFile(caFile)
+			if err != nil {
+				return err
+			}
+
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(caPEM) {
+				return fmt.Errorf("no certificates found in CA file %s", caFile)
+			}
+
+			config.RootCAs = pool
+		}
+
+		if certificateKeyFile != "" {
+			certificateKeyPEM, err := os.ReadFile(certificateKeyFile)
+			if err != nil {
+				return err
+			}
+
+			certificate, err := tls.X509KeyPair(certificateKeyPEM, certificateKeyPEM)
+			if err != nil {
+				return err
+			}
+
+			config.Certificates = []tls.Certificate{certificate}
+		}
+
+		options.tlsConfig = config
+
+		return nil
+	}
+}
+
+// WithReadPreference sets default read preference for all operations.
+func WithReadPreference(rp *readpref.ReadPref) Option {
+	return func(options *options) error {
+		options.readPreference = rp
+
+		return nil
+	}
+}
+
+// WithReadConcern sets default read concern for all operations.
+func WithReadConcern(rc *readconcern.ReadConcern) Option {
+	return func(options *options) error {
+		options.readConcern = rc
+
+		return nil
+	}
+}
+
+// WithWriteConcern sets default write concern for all operations.
+func WithWriteConcern(wc *writeconcern.WriteConcern) Option {
+	return func(options *options) error {
+		options.writeConcern = wc
+
+		return nil
+	}
+}
+
+// WithMetrics enables Prometheus metrics for mongoDB commands latency and failures by collection
+// and for connection pool state. Metrics are registered in default Prometheus registry.
+func WithMetrics() Option {
+	return func(options *options) error {
+		options.metricsEnabled = true
+
+		return nil
+	}
+}
+
+// WithTracing enables creation of child span for every mongoDB command via provided tracing.Provider.
+// Literal values are redacted from db.statement span attribute.
+func WithTracing(provider tracing.Provider) Option {
+	return func(options *options) error {
+		options.traceProvider = provider
+
+		return nil
+	}
+}
+
+// WithSlowCommandLogging enables logging of commands, which take longer than provided threshold,
+// with their redacted statement and duration.
+func WithSlowCommandLogging(logger logging.Logger, threshold time.Duration) Option {
+	return func(options *options) error {
+		options.slowCommandLogger = logger
+		options.slowCommandThreshold = threshold
+
+		return nil
+	}
+}
@@ -0,0 +1,175 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	mongoOptions "go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const idField = "_id"
+
+// Repository provides typed CRUD operations for documents of type T, stored in mongo.Collection.
+type Repository[T any] struct {
+	collection *mongo.Collection
+}
+
+// NewRepository creates *Repository for collection with provided name in provided database.
+func NewRepository[T any](
+	connector Connector,
+	database string,
+	collection string,
+	opts ...*mongoOptions.CollectionOptions,
+) (*Repository[T], error) {
+	db, err := connector.Database(database)
+	if err != nil {
+		return nil, err
+	}
+
+	coll, err := connector.Collection(db, collection, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repository[T]{collection: coll}, nil
+}
+
+// Collection returns mongo.Collection, used by Repository, for operations, which are not covered by Repository.
+func (r *Repository[T]) Collection() *mongo.Collection {
+	return r.collection
+}
+
+// FindByID returns document with provided ID. Returns NotFoundError, if document does not exist.
+func (r *Repository[T]) FindByID(ctx context.Context, id any) (*T, error) {
+	return r.FindOne(ctx, bson.D{{Key: idField, Value: id}})
+}
+
+// FindOne returns first document, which matches provided filter. Returns NotFoundError, if document does not exist.
+func (r *Repository[T]) FindOne(ctx context.Context, filter any) (*T, error) {
+	if r.collection == nil {
+		return nil, &NilCollectionError{}
+	}
+
+	var document T
+	if err := r.collection.FindOne(ctx, filter).Decode(&document); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &NotFoundError{BaseErr: err}
+		}
+
+		return nil, err
+	}
+
+	return &document, nil
+}
+
+// Find returns all documents, which match provided filter, with sort, projection and pagination via FindOption.
+func (r *Repository[T]) Find(ctx context.Context, filter any, opts ...FindOption) ([]T, error) {
+	if r.collection == nil {
+		return nil, &NilCollectionError{}
+	}
+
+	var options findOptions
+	for _, opt := range opts {
+		if err := opt(&options); err != nil {
+			return nil, err
+		}
+	}
+
+	if filter == nil {
+		filter = bson.D{}
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, options.toMongoOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	documents := make([]T, 0)
+	if err = cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+
+	return documents, nil
+}
+
+// Insert inserts provided document and returns its ID.
+func (r *Repository[T]) Insert(ctx context.Context, document T) (any, error) {
+	if r.collection == nil {
+		return nil, &NilCollectionError{}
+	}
+
+	result, err := r.collection.InsertOne(ctx, document)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.InsertedID, nil
+}
+
+// Upsert replaces document with provided ID or inserts it, if document does not exist.
+func (r *Repository[T]) Upsert(ctx context.Context, id any, document T) error {
+	if r.collection == nil {
+		return &NilCollectionError{}
+	}
+
+	_, err := r.collection.ReplaceOne(
+		ctx,
+		bson.D{{Key: idField, Value: id}},
+		document,
+		mongoOptions.Replace().SetUpsert(true),
+	)
+
+	return err
+}
+
+// Update applies provided update, for example bson.D{{"$set", bson.D{{"name", "new"}}}}, to document
+// with provided ID. Returns NotFoundError, if document does not exist.
+func (r *Repository[T]) Update(ctx context.Context, id any, update any) error {
+	if r.collection == nil {
+		return &NilCollectionError{}
+	}
+
+	result, err := r.collection.UpdateByID(ctx, id, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return &NotFoundError{}
+	}
+
+	return nil
+}
+
+// Delete deletes document with provided ID. Returns NotFoundError, if document does not exist.
+func (r *Repository[T]) Delete(ctx context.Context, id any) error {
+	if r.collection == nil {
+		return &NilCollectionError{}
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.D{{Key: idField, Value: id}})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return &NotFoundError{}
+	}
+
+	return nil
+}
+
+// Count returns number of documents, which match provided filter.
+func (r *Repository[T]) Count(ctx context.Context, filter any) (int64, error) {
+	if r.collection == nil {
+		return 0, &NilCollectionError{}
+	}
+
+	if filter == nil {
+		filter = bson.D{}
+	}
+
+	return r.collection.CountDocuments(ctx, filter)
+}
@@ -0,0 +1,155 @@
+//go:build integration
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type repositoryTestDocument struct {
+	ID   primitive.ObjectID `bson:"_id,omitempty"`
+	Name string             `bson:"name"`
+	Age  int                `bson:"age"`
+}
+
+func newTestRepository(t *testing.T) (*Repository[repositoryTestDocument], *CommonConnector) {
+	t.Helper()
+
+	connector, err := New(
+		context.Background(),
+		"mongodb://localhost:27017",
+		nil,
+		WithUsername("admin"),
+		WithPassword("secret"),
+		WithAuthSource("admin"),
+	)
+	require.NoError(t, err)
+
+	repository, err := NewRepository[repositoryTestDocument](connector, "test", "repository")
+	require.NoError(t, err)
+
+	_, err = repository.Collection().DeleteMany(context.Background(), bson.D{})
+	require.NoError(t, err)
+
+	return repository, connector
+}
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+	repository, connector := newTestRepository(t)
+
+	defer func() {
+		require.NoError(t, connector.Close(ctx))
+	}()
+
+	id, err := repository.Insert(ctx, repositoryTestDocument{Name: "Alice", Age: 30})
+	require.NoError(t, err)
+
+	_, err = repository.Insert(ctx, repositoryTestDocument{Name: "Bob", Age: 25})
+	require.NoError(t, err)
+
+	t.Run("find by ID", func(t *testing.T) {
+		document, err := repository.FindByID(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, "Alice", document.Name)
+	})
+
+	t.Run("find by ID not found", func(t *testing.T) {
+		document, err := repository.FindByID(ctx, primitive.NewObjectID())
+		require.Nil(t, document)
+
+		var notFoundErr *NotFoundError
+		require.True(t, errors.As(err, &notFoundErr))
+	})
+
+	t.Run("find with sort and pagination", func(t *testing.T) {
+		documents, err := repository.Find(
+			ctx,
+			nil,
+			WithFindSort(bson.D{{Key: "age", Value: 1}}),
+			WithFindPagination(1, 1),
+		)
+		require.NoError(t, err)
+		require.Len(t, documents, 1)
+		require.Equal(t, "Bob", documents[0].Name)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		err := repository.Update(ctx, id, bson.D{{Key: "$set", Value: bson.D{{Key: "age", Value: 31}}}})
+		require.NoError(t, err)
+
+		document, err := repository.FindByID(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, 31, document.Age)
+	})
+
+	t.Run("update not found", func(t *testing.T) {
+		err := repository.Update(
+			ctx,
+			primitive.NewObjectID(),
+			bson.D{{Key: "$set", Value: bson.D{{Key: "age", Value: 1}}}},
+		)
+
+		var notFoundErr *NotFoundError
+		require.True(t, errors.As(err, &notFoundErr))
+	})
+
+	t.Run("upsert", func(t *testing.T) {
+		newID := primitive.NewObjectID()
+		err := repository.Upsert(ctx, newID, repositoryTestDocument{ID: newID, Name: "Carol", Age: 40})
+		require.NoError(t, err)
+
+		count, err := repository.Count(ctx, nil)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), count)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, repository.Delete(ctx, id))
+
+		var notFoundErr *NotFoundError
+		require.True(t, errors.As(repository.Delete(ctx, id), &notFoundErr))
+	})
+}
+
+func TestRepositoryNilCollection(t *testing.T) {
+	ctx := context.Background()
+	repository := &Repository[repositoryTestDocument]{}
+
+	var nilCollectionErr *NilCollectionError
+
+	_, err := repository.FindByID(ctx, primitive.NewObjectID())
+	require.True(t, errors.As(err, &nilCollectionErr))
+
+	_, err = repository.Find(ctx, nil)
+	require.True(t, errors.As(err, &nilCollectionErr))
+
+	_, err = repository.Insert(ctx, repositoryTestDocument{})
+	require.True(t, errors.As(err, &nilCollectionErr))
+
+	err = repository.Upsert(ctx, primitive.NewObjectID(), repositoryTestDocument{})
+	require.True(t, errors.As(err, &nilCollectionErr))
+
+	err = repository.Update(ctx, primitive.NewObjectID(), bson.D{})
+	require.True(t, errors.As(err, &nilCollectionErr))
+
+	err = repository.Delete(ctx, primitive.NewObjectID())
+	require.True(t, errors.As(err, &nilCollectionErr))
+
+	_, err = repository.Count(ctx, nil)
+	require.True(t, errors.As(err, &nilCollectionErr))
+}
+
+func TestNewRepositoryNilClient(t *testing.T) {
+	repository, err := NewRepository[repositoryTestDocument](&CommonConnector{}, "test", "repository")
+	require.Nil(t, repository)
+
+	var nilClientErr *NilClientError
+	require.True(t, errors.As(err, &nilClientErr))
+}
@@ -0,0 +1,157 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	mongoOptions "go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// resumeTokenDocument is a document, where resume token is stored by CollectionResumeTokenStore.
+type resumeTokenDocument struct {
+	Key       string    `bson:"_id"`
+	Token     bson.Raw  `bson:"token"`
+	UpdatedAt time.Time `bson:"updatedAt"`
+}
+
+// MemoryResumeTokenStore stores resume tokens in memory. Tokens are lost on restart, so it is suitable
+// only for tests and consumers, which do not need to continue processing after restart.
+type MemoryResumeTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]bson.Raw
+}
+
+// NewMemoryResumeTokenStore creates *MemoryResumeTokenStore.
+func NewMemoryResumeTokenStore() *MemoryResumeTokenStore {
+	return &MemoryResumeTokenStore{
+		tokens: make(map[string]bson.Raw),
+	}
+}
+
+// Load returns resume token for provided key or nil, if token was not saved yet.
+func (s *MemoryResumeTokenStore) Load(_ context.Context, key string) (bson.Raw, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.tokens[key], nil
+}
+
+// Save saves resume token for provided key.
+func (s *MemoryResumeTokenStore) Save(_ context.Context, key string, token bson.Raw) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[key] = slices.Clone(token)
+
+	return nil
+}
+
+// CollectionResumeTokenStore stores resume tokens in mongoDB collection, one document per key.
+type CollectionResumeTokenStore struct {
+	collection *mongo.Collection
+}
+
+// NewCollectionResumeTokenStore creates *CollectionResumeTokenStore for provided collection.
+func NewCollectionResumeTokenStore(collection *mongo.Collection) (*CollectionResumeTokenStore, error) {
+	if collection == nil {
+		return nil, &NilCollectionError{}
+	}
+
+	return &CollectionResumeTokenStore{collection: collection}, nil
+}
+
+// Load returns resume token for provided key or nil, if token was not saved yet.
+func (s *CollectionResumeTokenStore) Load(ctx context.Context, key string) (bson.Raw, error) {
+	var document resumeTokenDocument
+
+	err := s.collection.FindOne(ctx, bson.D{{Key: idField, Value: key}}).Decode(&document)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return document.Token, nil
+}
+
+// Save saves resume token for provided key.
+func (s *CollectionResumeTokenStore) Save(ctx context.Context, key string, token bson.Raw) error {
+	_, err := s.collection.UpdateOne(
+		ctx,
+		bson.D{{Key: idField, Value: key}},
+		bson.D{
+			{Key: "$set", Value: bson.D{
+				{Key: "token", Value: token},
+				{Key: "updatedAt", Value: time.Now().UTC()},
+			}},
+		},
+		mongoOptions.Update().SetUpsert(true),
+	)
+
+	return err
+}
+
+// resumeTokenTracker tracks processing of change events by several goroutines and returns the token
+// of the last event, before which all events were processed. This guarantees, that no event is lost,
+// if consumer restarts from saved token.
+type resumeTokenTracker struct {
+	mu        sync.Mutex
+	next      uint64
+	committed uint64
+	processed map[uint64]bson.Raw
+	token     bson.Raw
+	version   uint64
+}
+
+// newResumeTokenTracker creates *resumeTokenTracker.
+func newResumeTokenTracker() *resumeTokenTracker {
+	return &resumeTokenTracker{
+		processed: make(map[uint64]bson.Raw),
+	}
+}
+
+// dispatch registers event, which is going to be processed, and returns its sequence number.
+func (t *resumeTokenTracker) dispatch() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	sequence := t.next
+	t.next++
+
+	return sequence
+}
+
+// complete marks event with provided sequence number as processed.
+func (t *resumeTokenTracker) complete(sequence uint64, token bson.Raw) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.processed[sequence] = token
+
+	for {
+		processedToken, ok := t.processed[t.committed]
+		if !ok {
+			return
+		}
+
+		delete(t.processed, t.committed)
+		t.committed++
+		t.token = processedToken
+		t.version++
+	}
+}
+
+// committedToken returns the last committed token and its version, which is incremented on every commit.
+func (t *resumeTokenTracker) committedToken() (bson.Raw, uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.token, t.version
+}
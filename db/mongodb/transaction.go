@@ -0,0 +1,147 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	transientTransactionErrorLabel      = "TransientTransactionError"
+	unknownTransactionCommitResultLabel = "UnknownTransactionCommitResult"
+)
+
+// Transactor executes functions within mongoDB transactions. It is declared apart from Connector not to break
+// its existing implementations and not to make mocks of Connector depend on this package.
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error, opts ...TransactionOption) error
+}
+
+// WithTransaction executes fn within mongoDB multi-document transaction. Session is propagated to fn via
+// context, so all operations, which use this context (including Repository calls), join the transaction.
+// If context already contains session with running transaction, fn joins it. If session of context has
+// no running transaction, new transaction is started in this session.
+// Transaction is retried on TransientTransactionError and commit is retried on UnknownTransactionCommitResult
+// until retry timeout, configured via WithTransactionRetryTimeout, is exceeded.
+func (c *CommonConnector) WithTransaction(
+	ctx context.Context,
+	fn func(ctx context.Context) error,
+	opts ...TransactionOption,
+) error {
+	if c.client == nil {
+		return &NilClientError{}
+	}
+
+	options := newTransactionOptions()
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return err
+		}
+	}
+
+	if session := mongo.SessionFromContext(ctx); session != nil {
+		if transactionRunning(session) {
+			return fn(ctx)
+		}
+
+		// Session is owned by caller, so it is not ended after transaction:
+		return runTransaction(ctx, session, fn, options)
+	}
+
+	session, err := c.client.StartSession()
+	if err != nil {
+		return err
+	}
+
+	defer session.EndSession(context.WithoutCancel(ctx))
+
+	return runTransaction(ctx, session, fn, options)
+}
+
+// transactionRunning checks, whether session has started transaction, which was not committed or aborted yet.
+func transactionRunning(session mongo.Session) bool {
+	xSession, ok := session.(mongo.XSession) //nolint:staticcheck // the only way to get transaction state of session
+	if !ok {
+		return false
+	}
+
+	return xSession.ClientSession().TransactionRunning()
+}
+
+// runTransaction executes fn within transaction of provided session with retries.
+func runTransaction(
+	ctx context.Context,
+	session mongo.Session,
+	fn func(ctx context.Context) error,
+	options *transactionOptions,
+) error {
+	deadline := time.Now().Add(options.retryTimeout)
+
+	for {
+		if err := session.StartTransaction(options.toMongoOptions()); err != nil {
+			return err
+		}
+
+		sessionCtx := mongo.NewSessionContext(ctx, session)
+
+		if err := fn(sessionCtx); err != nil {
+			// Aborting with non-cancellable context not to leave transaction open, if ctx was canceled:
+			_ = session.AbortTransaction(context.WithoutCancel(sessionCtx))
+
+			if hasErrorLabel(err, transientTransactionErrorLabel) && time.Now().Before(deadline) {
+				continue
+			}
+
+			return err
+		}
+
+		if err := ctx.Err(); err != nil {
+			_ = session.AbortTransaction(context.WithoutCancel(sessionCtx))
+
+			return err
+		}
+
+		retry, err := commitTransaction(sessionCtx, session, deadline)
+		if !retry {
+			return err
+		}
+	}
+}
+
+// commitTransaction commits transaction, retrying on UnknownTransactionCommitResult.
+// Returns true, if the whole transaction should be retried due to TransientTransactionError.
+func commitTransaction(ctx context.Context, session mongo.Session, deadline time.Time) (bool, error) {
+	for {
+		err := session.CommitTransaction(context.WithoutCancel(ctx))
+		if err == nil {
+			return false, nil
+		}
+
+		if time.Now().After(deadline) {
+			return false, err
+		}
+
+		var commandErr mongo.CommandError
+		if errors.As(err, &commandErr) && commandErr.IsMaxTimeMSExpiredError() {
+			return false, err
+		}
+
+		switch {
+		case hasErrorLabel(err, unknownTransactionCommitResultLabel):
+			continue
+		case hasErrorLabel(err, transientTransactionErrorLabel):
+			return true, err
+		default:
+			return false, err
+		}
+	}
+}
+
+// hasErrorLabel checks, whether error or any wrapped error has provided mongoDB error label.
+func hasErrorLabel(err error, label string) bool {
+	var labeledErr mongo.LabeledError
+
+	return errors.As(err, &labeledErr) && labeledErr.HasErrorLabel(label)
+}
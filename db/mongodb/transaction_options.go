@@ -0,0 +1,100 @@
+package mongodb
+
+import (
+	"time"
+
+	mongoOptions "go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+const defaultTransactionRetryTimeout = 120 * time.Second
+
+// newTransactionOptions creates *transactionOptions with default values.
+func newTransactionOptions() *transactionOptions {
+	return &transactionOptions{
+		retryTimeout: defaultTransactionRetryTimeout,
+	}
+}
+
+// transactionOptions represents options for mongoDB transaction configuration.
+type transactionOptions struct {
+	readConcern    *readconcern.ReadConcern
+	writeConcern   *writeconcern.WriteConcern
+	readPreference *readpref.ReadPref
+	maxCommitTime  *time.Duration
+	retryTimeout   time.Duration
+}
+
+// TransactionOption represents golang functional option pattern func for transaction configuration.
+type TransactionOption func(options *transactionOptions) error
+
+// toMongoOptions converts transactionOptions to *mongoOptions.TransactionOptions.
+func (o *transactionOptions) toMongoOptions() *mongoOptions.TransactionOptions {
+	opts := mongoOptions.Transaction()
+
+	if o.readConcern != nil {
+		opts.SetReadConcern(o.readConcern)
+	}
+
+	if o.writeConcern != nil {
+		opts.SetWriteConcern(o.writeConcern)
+	}
+
+	if o.readPreference != nil {
+		opts.SetReadPreference(o.readPreference)
+	}
+
+	if o.maxCommitTime != nil {
+		opts.SetMaxCommitTime(o.maxCommitTime)
+	}
+
+	return opts
+}
+
+// WithTransactionReadConcern sets read concern for transaction, for example readconcern.Snapshot().
+func WithTransactionReadConcern(readConcern *readconcern.ReadConcern) TransactionOption {
+	return func(options *transactionOptions) error {
+		options.readConcern = readConcern
+
+		return nil
+	}
+}
+
+// WithTransactionWriteConcern sets write concern for transaction, for example writeconcern.Majority().
+func WithTransactionWriteConcern(writeConcern *writeconcern.WriteConcern) TransactionOption {
+	return func(options *transactionOptions) error {
+		options.writeConcern = writeConcern
+
+		return nil
+	}
+}
+
+// WithTransactionReadPreference sets read preference for transaction. Transactions support only primary.
+func WithTransactionReadPreference(readPreference *readpref.ReadPref) TransactionOption {
+	return func(options *transactionOptions) error {
+		options.readPreference = readPreference
+
+		return nil
+	}
+}
+
+// WithTransactionMaxCommitTime sets maximum amount of time for single commit attempt.
+func WithTransactionMaxCommitTime(maxCommitTime time.Duration) TransactionOption {
+	return func(options *transactionOptions) error {
+		options.maxCommitTime = &maxCommitTime
+
+		return nil
+	}
+}
+
+// WithTransactionRetryTimeout sets total time, during which transaction is retried
+// on TransientTransactionError and UnknownTransactionCommitResult errors.
+func WithTransactionRetryTimeout(timeout time.Duration) TransactionOption {
+	return func(options *transactionOptions) error {
+		options.retryTimeout = timeout
+
+		return nil
+	}
+}
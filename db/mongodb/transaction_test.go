@@ -0,0 +1,165 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	mongoOptions "go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+func TestWithTransactionNilClient(t *testing.T) {
+	t.Parallel()
+
+	connector := &CommonConnector{}
+	called := false
+	err := connector.WithTransaction(
+		context.Background(),
+		func(_ context.Context) error {
+			called = true
+
+			return nil
+		},
+	)
+
+	var nilClientErr *NilClientError
+	if !errors.As(err, &nilClientErr) {
+		t.Errorf("WithTransaction() error = %v, want *NilClientError", err)
+	}
+
+	if called {
+		t.Error("fn should not be called for nil client")
+	}
+}
+
+func TestTransactionRunning(t *testing.T) {
+	t.Parallel()
+
+	// Client connects lazily, so session can be started without server:
+	client, err := mongo.Connect(context.Background(), mongoOptions.Client().ApplyURI("mongodb://localhost:1"))
+	if err != nil {
+		t.Fatalf("mongo.Connect() unexpected error: %v", err)
+	}
+
+	defer func() {
+		_ = client.Disconnect(context.Background())
+	}()
+
+	session, err := client.StartSession()
+	if err != nil {
+		t.Fatalf("StartSession() unexpected error: %v", err)
+	}
+
+	defer session.EndSession(context.Background())
+
+	if transactionRunning(session) {
+		t.Error("transactionRunning() = true for session without transaction, want false")
+	}
+
+	if err = session.StartTransaction(); err != nil {
+		t.Fatalf("StartTransaction() unexpected error: %v", err)
+	}
+
+	if !transactionRunning(session) {
+		t.Error("transactionRunning() = false for session with started transaction, want true")
+	}
+}
+
+func TestHasErrorLabel(t *testing.T) {
+	t.Parallel()
+
+	labeledErr := mongo.CommandError{
+		Name:   "WriteConflict",
+		Labels: []string{transientTransactionErrorLabel},
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		label    string
+		expected bool
+	}{
+		{
+			name:     "error with label",
+			err:      labeledErr,
+			label:    transientTransactionErrorLabel,
+			expected: true,
+		},
+		{
+			name:     "wrapped error with label",
+			err:      fmt.Errorf("operation failed: %w", labeledErr),
+			label:    transientTransactionErrorLabel,
+			expected: true,
+		},
+		{
+			name:     "error without provided label",
+			err:      labeledErr,
+			label:    unknownTransactionCommitResultLabel,
+			expected: false,
+		},
+		{
+			name:     "error without labels",
+			err:      errors.New("plain error"),
+			label:    transientTransactionErrorLabel,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if actual := hasErrorLabel(tt.err, tt.label); actual != tt.expected {
+				t.Errorf("hasErrorLabel() = %v, want %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTransactionOptionFunctions(t *testing.T) {
+	t.Parallel()
+
+	opts := newTransactionOptions()
+	if opts.retryTimeout != defaultTransactionRetryTimeout {
+		t.Errorf("retryTimeout = %v, want %v", opts.retryTimeout, defaultTransactionRetryTimeout)
+	}
+
+	for _, option := range []TransactionOption{
+		WithTransactionReadConcern(readconcern.Snapshot()),
+		WithTransactionWriteConcern(writeconcern.Majority()),
+		WithTransactionReadPreference(readpref.Primary()),
+		WithTransactionMaxCommitTime(time.Second),
+		WithTransactionRetryTimeout(time.Minute),
+	} {
+		if err := option(opts); err != nil {
+			t.Fatalf("option returned error: %v", err)
+		}
+	}
+
+	if opts.retryTimeout != time.Minute {
+		t.Errorf("retryTimeout = %v, want 1m", opts.retryTimeout)
+	}
+
+	mongoOpts := opts.toMongoOptions()
+	if mongoOpts.ReadConcern == nil || mongoOpts.ReadConcern.Level != readconcern.Snapshot().Level {
+		t.Errorf("ReadConcern = %v, want snapshot", mongoOpts.ReadConcern)
+	}
+
+	if mongoOpts.WriteConcern == nil {
+		t.Error("WriteConcern = nil, want majority")
+	}
+
+	if mongoOpts.ReadPreference == nil {
+		t.Error("ReadPreference = nil, want primary")
+	}
+
+	if mongoOpts.MaxCommitTime == nil || *mongoOpts.MaxCommitTime != time.Second {
+		t.Errorf("MaxCommitTime = %v, want 1s", mongoOpts.MaxCommitTime)
+	}
+}
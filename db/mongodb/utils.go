@@ -2,14 +2,88 @@ package mongodb
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 )
 
+const (
+	schemeStandard = "mongodb"
+	schemeSRV      = "mongodb+srv"
+)
+
+// BuildDsn builds connection string for mongoDB on base of provided Config. Credentials and options
+// are escaped according to connection string specification.
 func BuildDsn(config Config) string {
-	dsn := fmt.Sprintf(
-		"mongodb://%s:%d",
-		config.Host,
-		config.Port,
-	)
+	scheme := schemeStandard
+	if config.SRV {
+		scheme = schemeSRV
+	}
+
+	hosts := strings.Join(config.Hosts, ",")
+	if len(config.Hosts) == 0 {
+		hosts = config.Host
+		if !config.SRV {
+			hosts = fmt.Sprintf("%s:%d", config.Host, config.Port)
+		}
+	}
+
+	var credentials string
+	if config.Username != "" {
+		credentials = url.User(config.Username).String() + "@"
+		if config.Password != "" {
+			credentials = url.UserPassword(config.Username, config.Password).String() + "@"
+		}
+	}
+
+	dsn := fmt.Sprintf("%s://%s%s", scheme, credentials, hosts)
+
+	query := buildDsnQuery(config)
+	if config.Database == "" && len(query) == 0 {
+		return dsn
+	}
+
+	dsn += "/" + url.QueryEscape(config.Database)
+	if len(query) > 0 {
+		dsn += "?" + query.Encode()
+	}
 
 	return dsn
 }
+
+// buildDsnQuery builds connection string options for provided Config.
+func buildDsnQuery(config Config) url.Values {
+	query := url.Values{}
+
+	setIfNotEmpty := func(key, value string) {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	setIfNotEmpty("authSource", config.AuthSource)
+	setIfNotEmpty("authMechanism", config.AuthMechanism)
+	setIfNotEmpty("replicaSet", config.ReplicaSet)
+	setIfNotEmpty("appName", config.AppName)
+	setIfNotEmpty("tlsCAFile", config.TLSCAFile)
+	setIfNotEmpty("tlsCertificateKeyFile", config.TLSCertificateKeyFile)
+	setIfNotEmpty("readPreference", config.ReadPreference)
+	setIfNotEmpty("readConcernLevel", config.ReadConcernLevel)
+	setIfNotEmpty("w", config.WriteConcern)
+	setIfNotEmpty("compressors", strings.Join(config.Compressors, ","))
+
+	// SRV connection string enables TLS by default, so it is set only for standard scheme:
+	if config.TLS && !config.SRV {
+		query.Set("tls", "true")
+	}
+
+	if config.TLSInsecure {
+		query.Set("tlsInsecure", "true")
+	}
+
+	if config.RetryWrites != nil {
+		query.Set("retryWrites", strconv.FormatBool(*config.RetryWrites))
+	}
+
+	return query
+}
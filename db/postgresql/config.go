@@ -2,7 +2,8 @@ package postgresql
 
 import "time"
 
-// Config is a database config, on base of which new Connector is created.
+// Config is a database config, on base of which new Connector is created. DSN is built via BuildDsn,
+// other settings are applied via WithConfig.
 type Config struct {
 	Host         string
 	Port         int
@@ -12,6 +13,16 @@ type Config struct {
 	SSLMode      string
 	Driver       string
 	Pool         PoolConfig
+	Timeouts     Timeouts
+
+	// SlowQueryThreshold is a duration, after which query is logged as slow. Zero disables slow-query guard.
+	SlowQueryThreshold time.Duration
+
+	// StartupDeadline is a total time for waiting database startup. Zero means single connection attempt.
+	StartupDeadline time.Duration
+
+	// HealthCheckInterval is an interval of background health checks. Zero disables health checks.
+	HealthCheckInterval time.Duration
 }
 
 type PoolConfig struct {
@@ -3,14 +3,24 @@ package postgresql
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 
+	"github.com/DKhorkov/libs/db/health"
 	"github.com/DKhorkov/libs/logging"
 	_ "github.com/lib/pq" // Postgres driver
 )
 
 // New is constructor of CommonConnector. Gets database Config and logging.Logger to create an instance.
 func New(dsn, driver string, logger logging.Logger, opts ...PoolOption) (*CommonConnector, error) {
-	pool, err := connect(dsn, driver, opts...)
+	var options poolOptions
+	for _, opt := range opts {
+		err := opt(&options)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pool, err := connect(dsn, driver, logger, options)
 	if err != nil {
 		return nil, err
 	}
@@ -18,6 +28,18 @@ func New(dsn, driver string, logger logging.Logger, opts ...PoolOption) (*Common
 	dbConnector := &CommonConnector{
 		connectionsPool: pool,
 		logger:          logger,
+		timeouts:        options.timeouts,
+	}
+
+	if options.healthCheckInterval > 0 {
+		dbConnector.healthChecker = health.NewChecker(
+			pool.PingContext,
+			options.healthCheckInterval,
+			options.healthCheckTimeout,
+			options.healthCheckCallback,
+		)
+
+		dbConnector.healthChecker.Run()
 	}
 
 	return dbConnector, nil
@@ -27,24 +49,28 @@ func New(dsn, driver string, logger logging.Logger, opts ...PoolOption) (*Common
 type CommonConnector struct {
 	connectionsPool Pool
 	logger          logging.Logger
+	timeouts        Timeouts
+	healthChecker   *health.Checker
 }
 
 // connect connects to database and stores connections pool for later usage.
-func connect(dsn, driver string, opts ...PoolOption) (*sql.DB, error) {
-	var options poolOptions
-	for _, opt := range opts {
-		err := opt(&options)
-		if err != nil {
-			return nil, err
-		}
-	}
-
+func connect(dsn, driver string, logger logging.Logger, options poolOptions) (*sql.DB, error) {
 	pool, err := sql.Open(driver, dsn)
 	if err != nil {
 		return nil, err
 	}
 
-	if err = pool.Ping(); err != nil {
+	if options.slowQueryThreshold > 0 {
+		if pool, err = guardPool(pool, dsn, logger, options); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = health.Retry(context.Background(), options.retry, pool.PingContext); err != nil {
+		if closeErr := pool.Close(); closeErr != nil {
+			logging.LogError(logger, "Failed to close connections pool", closeErr)
+		}
+
 		return nil, err
 	}
 
@@ -56,13 +82,63 @@ func connect(dsn, driver string, opts ...PoolOption) (*sql.DB, error) {
 	return pool, nil
 }
 
+// guardPool replaces provided pool with the new one, which connections are observed by slow-query guard.
+func guardPool(pool *sql.DB, dsn string, logger logging.Logger, options poolOptions) (*sql.DB, error) {
+	databaseDriver := pool.Driver()
+
+	// sql.Open does not establish any connections, so pool is used only to get driver:
+	if err := pool.Close(); err != nil {
+		return nil, err
+	}
+
+	var connector driver.Connector = dsnConnector{dsn: dsn, driver: databaseDriver}
+	if driverContext, ok := databaseDriver.(driver.DriverContext); ok {
+		var err error
+		if connector, err = driverContext.OpenConnector(dsn); err != nil {
+			return nil, err
+		}
+	}
+
+	redactor := options.slowQueryArgsRedactor
+	if redactor == nil {
+		redactor = defaultSlowQueryArgsRedactor
+	}
+
+	return sql.OpenDB(
+		&guardedConnector{
+			connector: connector,
+			guard: &slowQueryGuard{
+				logger:    logger,
+				threshold: options.slowQueryThreshold,
+				redactor:  redactor,
+			},
+		},
+	), nil
+}
+
 // Connection creates connection with database, if not exists. Returns connection for external usage.
 func (connector *CommonConnector) Connection(ctx context.Context) (Connection, error) {
 	if connector.connectionsPool == nil {
 		return nil, &NilDBConnectionError{}
 	}
 
-	return connector.connectionsPool.Conn(ctx)
+	connection, err := connector.connectionsPool.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := connector.applyTimeouts(ctx, connection, false)
+	if err != nil {
+		CloseConnectionContext(ctx, connection, connector.logger)
+
+		return nil, err
+	}
+
+	if applied {
+		return &timeoutsConnection{Conn: connection}, nil
+	}
+
+	return connection, nil
 }
 
 // Transaction return database transaction object for external usage with atomicity of operations.
@@ -82,13 +158,49 @@ func (connector *CommonConnector) Transaction(
 		}
 	}
 
-	return connector.connectionsPool.BeginTx(
+	transaction, err := connector.connectionsPool.BeginTx(
 		ctx,
 		&sql.TxOptions{
 			ReadOnly:  options.readOnly,
 			Isolation: options.isolationLevel,
 		},
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = connector.applyTimeouts(ctx, transaction, true); err != nil {
+		if rollbackErr := transaction.Rollback(); rollbackErr != nil {
+			logging.LogErrorContext(ctx, connector.logger, "Failed to rollback transaction", rollbackErr)
+		}
+
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// applyTimeouts sets Connector default timeouts, overridden by timeouts from context, for provided
+// connection or transaction. Nothing is done, if no timeouts were configured. Reports, whether timeouts were set.
+// For transaction timeouts are set via SET LOCAL and are reset after transaction ends.
+// For connection timeouts are set for the whole session and are reset, when connection is closed.
+func (connector *CommonConnector) applyTimeouts(
+	ctx context.Context,
+	executor executor,
+	local bool,
+) (bool, error) {
+	overrides, _ := TimeoutsFromContext(ctx)
+	if connector.timeouts.IsZero() && overrides.IsZero() {
+		return false, nil
+	}
+
+	for _, query := range connector.timeouts.merge(overrides).queries(local) {
+		if _, err := executor.ExecContext(ctx, query); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
 }
 
 // Pool returns database connections pool.
@@ -96,8 +208,26 @@ func (connector *CommonConnector) Pool() Pool {
 	return connector.connectionsPool
 }
 
+// Healthy returns database health status. If health checks are disabled, connector is considered healthy
+// while connections pool exists.
+func (connector *CommonConnector) Healthy() bool {
+	if connector.connectionsPool == nil {
+		return false
+	}
+
+	if connector.healthChecker == nil {
+		return true
+	}
+
+	return connector.healthChecker.Healthy()
+}
+
 // Close closes pool of connections.
 func (connector *CommonConnector) Close() error {
+	if connector.healthChecker != nil {
+		connector.healthChecker.Stop()
+	}
+
 	if connector.connectionsPool == nil {
 		return nil
 	}
@@ -3,6 +3,12 @@ package postgresql_test
 import (
 	"context"
 	"database/sql"
+	sqldriver "database/sql/driver"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -200,3 +206,429 @@ func TestPool(t *testing.T) {
 		assert.Nil(t, connector.Pool())
 	})
 }
+
+// recordingDriver is a sqldriver.Driver, which records executed queries instead of executing them.
+type recordingDriver struct {
+	mu      sync.Mutex
+	queries []string
+}
+
+var recordingDriversCount atomic.Int64
+
+// newRecordingDriver registers new *recordingDriver and returns its name.
+func newRecordingDriver() (string, *recordingDriver) {
+	name := fmt.Sprintf("recording%d", recordingDriversCount.Add(1))
+	recorder := &recordingDriver{}
+	sql.Register(name, recorder)
+
+	return name, recorder
+}
+
+func (d *recordingDriver) Open(string) (sqldriver.Conn, error) {
+	return &recordingConn{driver: d}, nil
+}
+
+func (d *recordingDriver) executed() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return slices.Clone(d.queries)
+}
+
+type recordingConn struct {
+	driver *recordingDriver
+}
+
+func (c *recordingConn) Prepare(string) (sqldriver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c *recordingConn) Close() error {
+	return nil
+}
+
+func (c *recordingConn) Begin() (sqldriver.Tx, error) {
+	return recordingTx{}, nil
+}
+
+func (c *recordingConn) ExecContext(_ context.Context, query string, _ []sqldriver.NamedValue) (sqldriver.Result, error) {
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+
+	c.driver.queries = append(c.driver.queries, query)
+
+	return sqldriver.RowsAffected(0), nil
+}
+
+type recordingTx struct{}
+
+func (recordingTx) Commit() error {
+	return nil
+}
+
+func (recordingTx) Rollback() error {
+	return nil
+}
+
+func TestTimeouts(t *testing.T) {
+	t.Parallel()
+
+	t.Run("connection without timeouts does not execute SET queries", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		logger := loggermock.NewMockLogger(ctrl)
+		driverName, recorder := newRecordingDriver()
+		connector, err := postgresql2.New(dsn, driverName, logger)
+		require.NoError(t, err)
+
+		defer func() {
+			err = connector.Close()
+			require.NoError(t, err)
+		}()
+
+		connection, err := connector.Connection(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, connection.Close())
+		assert.Empty(t, recorder.executed())
+	})
+
+	t.Run("connection with default timeouts applies them on checkout and resets on close", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		logger := loggermock.NewMockLogger(ctrl)
+		driverName, recorder := newRecordingDriver()
+		connector, err := postgresql2.New(
+			dsn,
+			driverName,
+			logger,
+			postgresql2.WithStatementTimeout(time.Second),
+			postgresql2.WithLockTimeout(2*time.Second),
+			postgresql2.WithIdleInTransactionSessionTimeout(time.Minute),
+		)
+		require.NoError(t, err)
+
+		defer func() {
+			err = connector.Close()
+			require.NoError(t, err)
+		}()
+
+		connection, err := connector.Connection(context.Background())
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			[]string{
+				"SET statement_timeout = 1000",
+				"SET lock_timeout = 2000",
+				"SET idle_in_transaction_session_timeout = 60000",
+			},
+			recorder.executed(),
+		)
+
+		require.NoError(t, connection.Close())
+		assert.Equal(
+			t,
+			[]string{
+				"RESET statement_timeout",
+				"RESET lock_timeout",
+				"RESET idle_in_transaction_session_timeout",
+			},
+			recorder.executed()[3:],
+		)
+	})
+
+	t.Run("connection with timeouts from context resets them on close", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		logger := loggermock.NewMockLogger(ctrl)
+		driverName, recorder := newRecordingDriver()
+		connector, err := postgresql2.New(dsn, driverName, logger)
+		require.NoError(t, err)
+
+		defer func() {
+			err = connector.Close()
+			require.NoError(t, err)
+		}()
+
+		ctx := postgresql2.ContextWithTimeouts(
+			context.Background(),
+			postgresql2.Timeouts{Statement: time.Second},
+		)
+
+		connection, err := connector.Connection(ctx)
+		require.NoError(t, err)
+		require.NoError(t, connection.Close())
+		assert.Equal(
+			t,
+			[]string{
+				"SET statement_timeout = 1000",
+				"SET lock_timeout TO DEFAULT",
+				"SET idle_in_transaction_session_timeout TO DEFAULT",
+				"RESET statement_timeout",
+				"RESET lock_timeout",
+				"RESET idle_in_transaction_session_timeout",
+			},
+			recorder.executed(),
+		)
+	})
+
+	t.Run("transaction with timeouts from context applies them locally", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		logger := loggermock.NewMockLogger(ctrl)
+		driverName, recorder := newRecordingDriver()
+		connector, err := postgresql2.New(dsn, driverName, logger, postgresql2.WithLockTimeout(time.Second))
+		require.NoError(t, err)
+
+		defer func() {
+			err = connector.Close()
+			require.NoError(t, err)
+		}()
+
+		ctx := postgresql2.ContextWithTimeouts(
+			context.Background(),
+			postgresql2.Timeouts{Statement: 2 * time.Second},
+		)
+
+		transaction, err := connector.Transaction(ctx)
+		require.NoError(t, err)
+		require.NoError(t, transaction.Commit())
+		assert.Equal(
+			t,
+			[]string{
+				"SET LOCAL statement_timeout = 2000",
+				"SET LOCAL lock_timeout = 1000",
+				"SET LOCAL idle_in_transaction_session_timeout TO DEFAULT",
+			},
+			recorder.executed(),
+		)
+	})
+
+	t.Run("sub-millisecond timeouts are rounded up", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		logger := loggermock.NewMockLogger(ctrl)
+		driverName, recorder := newRecordingDriver()
+		connector, err := postgresql2.New(dsn, driverName, logger, postgresql2.WithLockTimeout(500*time.Microsecond))
+		require.NoError(t, err)
+
+		defer func() {
+			err = connector.Close()
+			require.NoError(t, err)
+		}()
+
+		ctx := postgresql2.ContextWithTimeouts(
+			context.Background(),
+			postgresql2.Timeouts{Statement: 1500 * time.Microsecond},
+		)
+
+		transaction, err := connector.Transaction(ctx)
+		require.NoError(t, err)
+		require.NoError(t, transaction.Commit())
+		assert.Equal(
+			t,
+			[]string{
+				"SET LOCAL statement_timeout = 2",
+				"SET LOCAL lock_timeout = 1",
+				"SET LOCAL idle_in_transaction_session_timeout TO DEFAULT",
+			},
+			recorder.executed(),
+		)
+	})
+
+	t.Run("timeouts of config are applied", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		logger := loggermock.NewMockLogger(ctrl)
+		driverName, recorder := newRecordingDriver()
+		connector, err := postgresql2.New(
+			dsn,
+			driverName,
+			logger,
+			postgresql2.WithLockTimeout(time.Second),
+			postgresql2.WithConfig(
+				postgresql2.Config{
+					Pool:     postgresql2.PoolConfig{MaxOpenConnections: 3},
+					Timeouts: postgresql2.Timeouts{Statement: 2 * time.Second},
+				},
+			),
+		)
+		require.NoError(t, err)
+
+		defer func() {
+			err = connector.Close()
+			require.NoError(t, err)
+		}()
+
+		assert.Equal(t, 3, connector.Pool().Stats().MaxOpenConnections)
+
+		transaction, err := connector.Transaction(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, transaction.Rollback())
+		assert.Equal(
+			t,
+			[]string{
+				"SET LOCAL statement_timeout = 2000",
+				"SET LOCAL lock_timeout = 1000",
+				"SET LOCAL idle_in_transaction_session_timeout TO DEFAULT",
+			},
+			recorder.executed(),
+		)
+	})
+}
+
+func TestSlowQueryGuard(t *testing.T) {
+	t.Parallel()
+
+	t.Run("slow query is logged with redacted args", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		logger := loggermock.NewMockLogger(ctrl)
+		connector, err := postgresql2.New(
+			dsn,
+			driver,
+			logger,
+			postgresql2.WithSlowQueryThreshold(time.Nanosecond),
+		)
+		require.NoError(t, err)
+
+		defer func() {
+			err = connector.Close()
+			require.NoError(t, err)
+		}()
+
+		var loggedArgs []any
+
+		logger.
+			EXPECT().
+			WarnContext(gomock.Any(), "Slow query detected", gomock.Any()).
+			Do(func(_ context.Context, _ string, args ...any) {
+				loggedArgs = args
+			}).
+			MinTimes(1)
+
+		var result string
+
+		err = connector.Pool().QueryRowContext(context.Background(), "SELECT ?", "secret").Scan(&result)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", result)
+
+		require.Contains(t, loggedArgs, "SELECT ?")
+		require.Contains(t, loggedArgs, []any{"<redacted string>"})
+		require.NotContains(t, loggedArgs, []any{"secret"})
+	})
+
+	t.Run("fast query is not logged", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		logger := loggermock.NewMockLogger(ctrl)
+		connector, err := postgresql2.New(
+			dsn,
+			driver,
+			logger,
+			postgresql2.WithSlowQueryThreshold(time.Hour),
+			postgresql2.WithSlowQueryArgsRedactor(func(arg any) any { return arg }),
+		)
+		require.NoError(t, err)
+
+		defer func() {
+			err = connector.Close()
+			require.NoError(t, err)
+		}()
+
+		_, err = connector.Pool().ExecContext(context.Background(), "SELECT 1")
+		require.NoError(t, err)
+	})
+}
+
+func TestHealthy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("connector without health checks is healthy", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		logger := loggermock.NewMockLogger(ctrl)
+		connector, err := postgresql2.New(dsn, driver, logger)
+		require.NoError(t, err)
+
+		assert.True(t, connector.Healthy())
+		require.NoError(t, connector.Close())
+	})
+
+	t.Run("connector with health checks is healthy", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		logger := loggermock.NewMockLogger(ctrl)
+		connector, err := postgresql2.New(
+			dsn,
+			driver,
+			logger,
+			postgresql2.WithHealthCheckInterval(time.Millisecond),
+			postgresql2.WithHealthCheckTimeout(time.Second),
+			postgresql2.WithHealthCheckCallback(func(_ bool) {}),
+		)
+		require.NoError(t, err)
+
+		time.Sleep(10 * time.Millisecond)
+		assert.True(t, connector.Healthy())
+		require.NoError(t, connector.Close())
+	})
+
+	t.Run("nil connections pool", func(t *testing.T) {
+		t.Parallel()
+
+		connector := &postgresql2.CommonConnector{}
+		assert.False(t, connector.Healthy())
+	})
+}
+
+func TestConnectionRetry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("connection attempts are retried until deadline", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		logger := loggermock.NewMockLogger(ctrl)
+		startedAt := time.Now()
+		connector, err := postgresql2.New(
+			"file:/nonexistent/directory/database.sqlite?mode=ro",
+			driver,
+			logger,
+			postgresql2.WithConnectionRetryDeadline(50*time.Millisecond),
+			postgresql2.WithConnectionRetryBackoff(time.Millisecond, 10*time.Millisecond),
+		)
+
+		require.Error(t, err)
+		assert.Nil(t, connector)
+		assert.GreaterOrEqual(t, time.Since(startedAt), 50*time.Millisecond)
+	})
+
+	t.Run("startup deadline of config is applied", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		logger := loggermock.NewMockLogger(ctrl)
+		startedAt := time.Now()
+		connector, err := postgresql2.New(
+			"file:/nonexistent/directory/database.sqlite?mode=ro",
+			driver,
+			logger,
+			postgresql2.WithConfig(postgresql2.Config{StartupDeadline: 50 * time.Millisecond}),
+			postgresql2.WithConnectionRetryBackoff(time.Millisecond, 10*time.Millisecond),
+		)
+
+		require.Error(t, err)
+		assert.Nil(t, connector)
+		assert.GreaterOrEqual(t, time.Since(startedAt), 50*time.Millisecond)
+	})
+}
@@ -9,7 +9,7 @@ import (
 
 // Connector represents abstraction to work with Database according dependency inversion principal relying on methods.
 //
-//go:generate mockgen -source=interfaces.go -destination=mocks/connector.go -package=mocks -exclude_interfaces=Transaction,Pool,Connection
+//go:generate mockgen -source=interfaces.go -destination=mocks/connector.go -package=mocks -exclude_interfaces=Transaction,Pool,Connection,HealthReporter
 type Connector interface {
 	Close() error
 	Transaction(ctx context.Context, opts ...TransactionOption) (Transaction, error)
@@ -17,10 +17,18 @@ type Connector interface {
 	Pool() Pool
 }
 
+// HealthReporter represents connector, which reports database health status. It is separated from Connector
+// not to break its existing implementations.
+//
+//go:generate mockgen -source=interfaces.go -destination=mocks/health_reporter.go -package=mocks -exclude_interfaces=Connector,Transaction,Pool,Connection
+type HealthReporter interface {
+	Healthy() bool
+}
+
 // Transaction represents abstraction of Database to comply Atomicity principle
 // according dependency inversion principal relying on methods.
 //
-//go:generate mockgen -source=interfaces.go -destination=mocks/transaction.go -package=mocks -exclude_interfaces=Connector,Pool,Connection
+//go:generate mockgen -source=interfaces.go -destination=mocks/transaction.go -package=mocks -exclude_interfaces=Connector,Pool,Connection,HealthReporter
 type Transaction interface {
 	Commit() error
 	Rollback() error
@@ -38,7 +46,7 @@ type Transaction interface {
 
 // Connection represents abstraction of Database to execute any operation with Database
 //
-//go:generate mockgen -source=interfaces.go -destination=mocks/connection.go -package=mocks -exclude_interfaces=Connector,Transaction,Pool
+//go:generate mockgen -source=interfaces.go -destination=mocks/connection.go -package=mocks -exclude_interfaces=Connector,Transaction,Pool,HealthReporter
 type Connection interface {
 	PingContext(ctx context.Context) error
 	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
@@ -52,7 +60,7 @@ type Connection interface {
 
 // Pool represents abstraction of Database to work with connections and transactions
 //
-//go:generate mockgen -source=interfaces.go -destination=mocks/pool.go -package=mocks -exclude_interfaces=Connector,Transaction,Connection
+//go:generate mockgen -source=interfaces.go -destination=mocks/pool.go -package=mocks -exclude_interfaces=Connector,Transaction,Connection,HealthReporter
 type Pool interface {
 	PingContext(ctx context.Context) error
 	Ping() error
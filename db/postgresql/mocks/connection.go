@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=interfaces.go -destination=mocks/connection.go -package=mocks -exclude_interfaces=Connector,Transaction,Pool
+//	mockgen -source=interfaces.go -destination=mocks/connection.go -package=mocks -exclude_interfaces=Connector,Transaction,Pool,HealthReporter
 //
 
 // Package mocks is a generated GoMock package.
@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=interfaces.go -destination=mocks/connector.go -package=mocks -exclude_interfaces=Transaction,Pool,Connection
+//	mockgen -source=interfaces.go -destination=mocks/connector.go -package=mocks -exclude_interfaces=Transaction,Pool,Connection,HealthReporter
 //
 
 // Package mocks is a generated GoMock package.
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interfaces.go -destination=mocks/health_reporter.go -package=mocks -exclude_interfaces=Connector,Transaction,Pool,Connection
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHealthReporter is a mock of HealthReporter interface.
+type MockHealthReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthReporterMockRecorder
+	isgomock struct{}
+}
+
+// MockHealthReporterMockRecorder is the mock recorder for MockHealthReporter.
+type MockHealthReporterMockRecorder struct {
+	mock *MockHealthReporter
+}
+
+// NewMockHealthReporter creates a new mock instance.
+func NewMockHealthReporter(ctrl *gomock.Controller) *MockHealthReporter {
+	mock := &MockHealthReporter{ctrl: ctrl}
+	mock.recorder = &MockHealthReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthReporter) EXPECT() *MockHealthReporterMockRecorder {
+	return m.recorder
+}
+
+// Healthy mocks base method.
+func (m *MockHealthReporter) Healthy() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Healthy")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Healthy indicates an expected call of Healthy.
+func (mr *MockHealthReporterMockRecorder) Healthy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Healthy", reflect.TypeOf((*MockHealthReporter)(nil).Healthy))
+}
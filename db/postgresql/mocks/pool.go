@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=interfaces.go -destination=mocks/pool.go -package=mocks -exclude_interfaces=Connector,Transaction,Connection
+//	mockgen -source=interfaces.go -destination=mocks/pool.go -package=mocks -exclude_interfaces=Connector,Transaction,Connection,HealthReporter
 //
 
 // Package mocks is a generated GoMock package.
@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=interfaces.go -destination=mocks/transaction.go -package=mocks -exclude_interfaces=Connector,Pool,Connection
+//	mockgen -source=interfaces.go -destination=mocks/transaction.go -package=mocks -exclude_interfaces=Connector,Pool,Connection,HealthReporter
 //
 
 // Package mocks is a generated GoMock package.
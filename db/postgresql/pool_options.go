@@ -2,6 +2,8 @@ package postgresql
 
 import (
 	"time"
+
+	"github.com/DKhorkov/libs/db/health"
 )
 
 // poolOptions represents options for *sql.DB configuration.
@@ -10,11 +12,57 @@ type poolOptions struct {
 	maxIdleConnections    int
 	maxConnectionLifetime time.Duration
 	maxConnectionIdleTime time.Duration
+	timeouts              Timeouts
+	slowQueryThreshold    time.Duration
+	slowQueryArgsRedactor func(arg any) any
+	retry                 health.RetryConfig
+	healthCheckInterval   time.Duration
+	healthCheckTimeout    time.Duration
+	healthCheckCallback   func(healthy bool)
 }
 
 // PoolOption represents golang functional option pattern func for connections pool configuration.
 type PoolOption func(options *poolOptions) error
 
+// WithConfig applies pool settings, default timeouts, slow-query threshold, startup deadline and health checks
+// interval of Config.
+// Zero values of Config are ignored, so options, provided before, are not reset by them.
+func WithConfig(config Config) PoolOption {
+	return func(options *poolOptions) error {
+		if config.Pool.MaxOpenConnections != 0 {
+			options.maxOpenConnections = config.Pool.MaxOpenConnections
+		}
+
+		if config.Pool.MaxIdleConnections != 0 {
+			options.maxIdleConnections = config.Pool.MaxIdleConnections
+		}
+
+		if config.Pool.MaxConnectionLifetime != 0 {
+			options.maxConnectionLifetime = config.Pool.MaxConnectionLifetime
+		}
+
+		if config.Pool.MaxConnectionIdleTime != 0 {
+			options.maxConnectionIdleTime = config.Pool.MaxConnectionIdleTime
+		}
+
+		options.timeouts = options.timeouts.merge(config.Timeouts)
+
+		if config.SlowQueryThreshold != 0 {
+			options.slowQueryThreshold = config.SlowQueryThreshold
+		}
+
+		if config.StartupDeadline != 0 {
+			options.retry.Deadline = config.StartupDeadline
+		}
+
+		if config.HealthCheckInterval != 0 {
+			options.healthCheckInterval = config.HealthCheckInterval
+		}
+
+		return nil
+	}
+}
+
 // WithMaxOpenConnections sets maximum opened connections in database connections pool.
 func WithMaxOpenConnections(num int) PoolOption {
 	return func(options *poolOptions) error {
@@ -51,3 +99,101 @@ func WithMaxConnectionIdleTime(idleTime time.Duration) PoolOption {
 		return nil
 	}
 }
+
+// WithStatementTimeout sets default statement_timeout, which is applied to every connection and transaction
+// on checkout via Connector.
+func WithStatementTimeout(timeout time.Duration) PoolOption {
+	return func(options *poolOptions) error {
+		options.timeouts.Statement = timeout
+
+		return nil
+	}
+}
+
+// WithLockTimeout sets default lock_timeout, which is applied to every connection and transaction
+// on checkout via Connector.
+func WithLockTimeout(timeout time.Duration) PoolOption {
+	return func(options *poolOptions) error {
+		options.timeouts.Lock = timeout
+
+		return nil
+	}
+}
+
+// WithIdleInTransactionSessionTimeout sets default idle_in_transaction_session_timeout, which is applied to every
+// connection and transaction on checkout via Connector.
+func WithIdleInTransactionSessionTimeout(timeout time.Duration) PoolOption {
+	return func(options *poolOptions) error {
+		options.timeouts.IdleInTransactionSession = timeout
+
+		return nil
+	}
+}
+
+// WithSlowQueryThreshold enables slow-query guard: every query, which takes longer than provided threshold,
+// is logged with its SQL text, redacted args and duration.
+func WithSlowQueryThreshold(threshold time.Duration) PoolOption {
+	return func(options *poolOptions) error {
+		options.slowQueryThreshold = threshold
+
+		return nil
+	}
+}
+
+// WithSlowQueryArgsRedactor sets redactor for query args, which are logged by slow-query guard.
+// By default, all args are replaced with their types not to store sensitive data in logs.
+func WithSlowQueryArgsRedactor(redactor func(arg any) any) PoolOption {
+	return func(options *poolOptions) error {
+		options.slowQueryArgsRedactor = redactor
+
+		return nil
+	}
+}
+
+// WithConnectionRetryDeadline sets total time for waiting database startup, during which connection attempts
+// are retried with backoff. By default, connection is attempted only once.
+func WithConnectionRetryDeadline(deadline time.Duration) PoolOption {
+	return func(options *poolOptions) error {
+		options.retry.Deadline = deadline
+
+		return nil
+	}
+}
+
+// WithConnectionRetryBackoff sets initial and maximum delays between connection attempts.
+func WithConnectionRetryBackoff(initial, maxBackoff time.Duration) PoolOption {
+	return func(options *poolOptions) error {
+		options.retry.InitialBackoff = initial
+		options.retry.MaxBackoff = maxBackoff
+
+		return nil
+	}
+}
+
+// WithHealthCheckInterval enables background health checks of database with provided interval.
+func WithHealthCheckInterval(interval time.Duration) PoolOption {
+	return func(options *poolOptions) error {
+		options.healthCheckInterval = interval
+
+		return nil
+	}
+}
+
+// WithHealthCheckTimeout sets timeout for single health check. By default, health check interval is used.
+func WithHealthCheckTimeout(timeout time.Duration) PoolOption {
+	return func(options *poolOptions) error {
+		options.healthCheckTimeout = timeout
+
+		return nil
+	}
+}
+
+// WithHealthCheckCallback sets callback, which is called every time database health status changes.
+// Can be used for readiness probes.
+func WithHealthCheckCallback(callback func(healthy bool)) PoolOption {
+	return func(options *poolOptions) error {
+		options.healthCheckCallback = callback
+
+		return nil
+	}
+}
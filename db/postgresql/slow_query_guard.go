@@ -0,0 +1,349 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+)
+
+var (
+	errNamedParametersNotSupported = errors.New("sql: driver does not support the use of Named Parameters")
+	errIsolationLevelNotSupported  = errors.New("sql: driver does not support non-default isolation level")
+	errReadOnlyNotSupported        = errors.New("sql: driver does not support read-only transactions")
+)
+
+// defaultSlowQueryArgsRedactor replaces arg with its type not to store sensitive data in logs.
+func defaultSlowQueryArgsRedactor(arg any) any {
+	return fmt.Sprintf("<redacted %T>", arg)
+}
+
+// slowQueryGuard logs queries, which take longer than threshold.
+type slowQueryGuard struct {
+	logger    logging.Logger
+	threshold time.Duration
+	redactor  func(arg any) any
+}
+
+// observe logs query, if it took longer than guard threshold.
+func (g *slowQueryGuard) observe(
+	ctx context.Context,
+	query string,
+	args []driver.NamedValue,
+	startedAt time.Time,
+) {
+	duration := time.Since(startedAt)
+	if duration < g.threshold {
+		return
+	}
+
+	redactedArgs := make([]any, 0, len(args))
+	for _, arg := range args {
+		redactedArgs = append(redactedArgs, g.redactor(arg.Value))
+	}
+
+	g.logger.WarnContext(
+		ctx,
+		"Slow query detected",
+		"Query",
+		query,
+		"Args",
+		redactedArgs,
+		"Duration",
+		duration.String(),
+		"Threshold",
+		g.threshold.String(),
+	)
+}
+
+// guardedConnector wraps driver.Connector to create connections, observed by slowQueryGuard.
+type guardedConnector struct {
+	connector driver.Connector
+	guard     *slowQueryGuard
+}
+
+// Connect creates new guarded connection.
+func (c *guardedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &guardedConn{conn: conn, guard: c.guard}, nil
+}
+
+// Driver returns underlying driver.
+func (c *guardedConnector) Driver() driver.Driver {
+	return c.connector.Driver()
+}
+
+// dsnConnector is driver.Connector for drivers, which do not implement driver.DriverContext.
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+// Connect opens new connection using DSN.
+func (c dsnConnector) Connect(_ context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+// Driver returns underlying driver.
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// guardedConn wraps driver.Conn and passes executed queries to slowQueryGuard.
+type guardedConn struct {
+	conn  driver.Conn
+	guard *slowQueryGuard
+}
+
+// Prepare returns a prepared statement, bound to this connection.
+func (c *guardedConn) Prepare(query string) (driver.Stmt, error) {
+	stmt, err := c.conn.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &guardedStmt{stmt: stmt, conn: c.conn, query: query, guard: c.guard}, nil
+}
+
+// PrepareContext returns a prepared statement, bound to this connection.
+func (c *guardedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	preparer, ok := c.conn.(driver.ConnPrepareContext)
+	if !ok {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		return c.Prepare(query)
+	}
+
+	stmt, err := preparer.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &guardedStmt{stmt: stmt, conn: c.conn, query: query, guard: c.guard}, nil
+}
+
+// Close closes underlying connection.
+func (c *guardedConn) Close() error {
+	return c.conn.Close()
+}
+
+// Begin starts and returns a new transaction.
+//
+// Deprecated: Drivers should implement ConnBeginTx instead (or additionally).
+func (c *guardedConn) Begin() (driver.Tx, error) {
+	return c.conn.Begin() //nolint:staticcheck // required by driver.Conn interface
+}
+
+// BeginTx starts and returns a new transaction.
+func (c *guardedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+
+	if opts.Isolation != driver.IsolationLevel(0) {
+		return nil, errIsolationLevelNotSupported
+	}
+
+	if opts.ReadOnly {
+		return nil, errReadOnlyNotSupported
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.Begin()
+}
+
+// QueryContext executes a query, that may return rows, and observes its duration.
+// If underlying connection does not implement driver.QueryerContext, driver.ErrSkip is returned
+// for database/sql to use prepared statement instead.
+func (c *guardedConn) QueryContext(
+	ctx context.Context,
+	query string,
+	args []driver.NamedValue,
+) (driver.Rows, error) {
+	queryer, ok := c.conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	startedAt := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+
+	if !errors.Is(err, driver.ErrSkip) {
+		c.guard.observe(ctx, query, args, startedAt)
+	}
+
+	return rows, err
+}
+
+// ExecContext executes a query, that does not return rows, and observes its duration.
+// If underlying connection does not implement driver.ExecerContext, driver.ErrSkip is returned
+// for database/sql to use prepared statement instead.
+func (c *guardedConn) ExecContext(
+	ctx context.Context,
+	query string,
+	args []driver.NamedValue,
+) (driver.Result, error) {
+	execer, ok := c.conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	startedAt := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+
+	if !errors.Is(err, driver.ErrSkip) {
+		c.guard.observe(ctx, query, args, startedAt)
+	}
+
+	return result, err
+}
+
+// Ping verifies that connection is still alive.
+func (c *guardedConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+
+	return nil
+}
+
+// ResetSession is called prior to executing a query on the connection, if it has been used before.
+func (c *guardedConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+
+	return nil
+}
+
+// IsValid is called prior to placing the connection into the connection pool.
+func (c *guardedConn) IsValid() bool {
+	if validator, ok := c.conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+
+	return true
+}
+
+// CheckNamedValue is called before passing arguments to the driver.
+func (c *guardedConn) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := c.conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+
+	return driver.ErrSkip
+}
+
+// guardedStmt wraps driver.Stmt and passes executed statements to slowQueryGuard.
+type guardedStmt struct {
+	stmt  driver.Stmt
+	conn  driver.Conn
+	query string
+	guard *slowQueryGuard
+}
+
+// Close closes underlying statement.
+func (s *guardedStmt) Close() error {
+	return s.stmt.Close()
+}
+
+// NumInput returns the number of placeholder parameters.
+func (s *guardedStmt) NumInput() int {
+	return s.stmt.NumInput()
+}
+
+// Exec executes a query, that does not return rows.
+//
+// Deprecated: Drivers should implement StmtExecContext instead (or additionally).
+func (s *guardedStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.stmt.Exec(args) //nolint:staticcheck // required by driver.Stmt interface
+}
+
+// Query executes a query, that may return rows.
+//
+// Deprecated: Drivers should implement StmtQueryContext instead (or additionally).
+func (s *guardedStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.stmt.Query(args) //nolint:staticcheck // required by driver.Stmt interface
+}
+
+// ExecContext executes a query, that does not return rows, and observes its duration.
+func (s *guardedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	startedAt := time.Now()
+	defer s.guard.observe(ctx, s.query, args, startedAt)
+
+	if execer, ok := s.stmt.(driver.StmtExecContext); ok {
+		return execer.ExecContext(ctx, args)
+	}
+
+	values, err := namedValuesToValues(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.Exec(values)
+}
+
+// QueryContext executes a query, that may return rows, and observes its duration.
+func (s *guardedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	startedAt := time.Now()
+	defer s.guard.observe(ctx, s.query, args, startedAt)
+
+	if queryer, ok := s.stmt.(driver.StmtQueryContext); ok {
+		return queryer.QueryContext(ctx, args)
+	}
+
+	values, err := namedValuesToValues(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.Query(values)
+}
+
+// CheckNamedValue is called before passing arguments to the driver. Statement checker is preferred
+// over connection checker, as database/sql does.
+func (s *guardedStmt) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := s.stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+
+	if checker, ok := s.conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+
+	return driver.ErrSkip
+}
+
+// namedValuesToValues converts driver.NamedValue to driver.Value for drivers without context support.
+func namedValuesToValues(named []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(named))
+	for i, value := range named {
+		if value.Name != "" {
+			return nil, errNamedParametersNotSupported
+		}
+
+		values[i] = value.Value
+	}
+
+	return values, nil
+}
@@ -0,0 +1,144 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/DKhorkov/libs/contextlib"
+)
+
+const (
+	timeoutsContextKey = "postgresqlTimeouts"
+
+	statementTimeoutSetting                = "statement_timeout"
+	lockTimeoutSetting                     = "lock_timeout"
+	idleInTransactionSessionTimeoutSetting = "idle_in_transaction_session_timeout"
+
+	resetTimeoutsTimeout = 5 * time.Second
+)
+
+// timeoutSettings are names of PostgreSQL settings, which are managed via Timeouts.
+var timeoutSettings = []string{
+	statementTimeoutSetting,
+	lockTimeoutSetting,
+	idleInTransactionSessionTimeoutSetting,
+}
+
+// executor represents connection or transaction, which timeouts are applied to.
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Timeouts represents PostgreSQL session timeouts. Zero value of any field means, that timeout is not set.
+type Timeouts struct {
+	Statement                time.Duration
+	Lock                     time.Duration
+	IdleInTransactionSession time.Duration
+}
+
+// IsZero reports, whether none of timeouts is set.
+func (t Timeouts) IsZero() bool {
+	return t.Statement == 0 && t.Lock == 0 && t.IdleInTransactionSession == 0
+}
+
+// merge returns Timeouts, where non-zero fields of overrides replace the values of t.
+func (t Timeouts) merge(overrides Timeouts) Timeouts {
+	if overrides.Statement != 0 {
+		t.Statement = overrides.Statement
+	}
+
+	if overrides.Lock != 0 {
+		t.Lock = overrides.Lock
+	}
+
+	if overrides.IdleInTransactionSession != 0 {
+		t.IdleInTransactionSession = overrides.IdleInTransactionSession
+	}
+
+	return t
+}
+
+// queries builds SET queries for provided Timeouts. Not set timeouts are reset to session defaults
+// not to inherit values, which were set for connection by previous user.
+// If local is true, SET LOCAL is used to apply timeouts only within current transaction.
+func (t Timeouts) queries(local bool) []string {
+	command := "SET"
+	if local {
+		command = "SET LOCAL"
+	}
+
+	settings := []struct {
+		name  string
+		value time.Duration
+	}{
+		{name: statementTimeoutSetting, value: t.Statement},
+		{name: lockTimeoutSetting, value: t.Lock},
+		{name: idleInTransactionSessionTimeoutSetting, value: t.IdleInTransactionSession},
+	}
+
+	queries := make([]string, 0, len(settings))
+	for _, setting := range settings {
+		if setting.value == 0 {
+			queries = append(queries, fmt.Sprintf("%s %s TO DEFAULT", command, setting.name))
+
+			continue
+		}
+
+		queries = append(
+			queries,
+			fmt.Sprintf("%s %s = %d", command, setting.name, milliseconds(setting.value)),
+		)
+	}
+
+	return queries
+}
+
+// milliseconds converts duration to whole milliseconds, rounding up, so that non-zero timeout shorter
+// than millisecond does not become 0, which disables timeout in PostgreSQL.
+func milliseconds(duration time.Duration) int64 {
+	return int64((duration + time.Millisecond - 1) / time.Millisecond)
+}
+
+// timeoutsConnection is a Connection, which timeouts were set for the whole session on checkout via Connector.
+// Timeouts are reset, when connection is returned to pool, so they are not inherited by next users of connection,
+// including ones, which get it via Pool.
+type timeoutsConnection struct {
+	*sql.Conn
+}
+
+// Close resets session timeouts and returns connection to pool. If timeouts can not be reset,
+// connection is discarded.
+func (c *timeoutsConnection) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), resetTimeoutsTimeout)
+	defer cancel()
+
+	for _, setting := range timeoutSettings {
+		if _, err := c.Conn.ExecContext(ctx, "RESET "+setting); err != nil {
+			// Returning driver.ErrBadConn from Raw makes pool close connection instead of reusing it:
+			_ = c.Conn.Raw(func(any) error { return driver.ErrBadConn })
+
+			return err
+		}
+	}
+
+	return c.Conn.Close()
+}
+
+// ContextWithTimeouts returns context with Timeouts, which override Connector default timeouts
+// for connection or transaction, created with this context.
+func ContextWithTimeouts(ctx context.Context, timeouts Timeouts) context.Context {
+	return contextlib.WithValue(ctx, timeoutsContextKey, timeouts)
+}
+
+// TimeoutsFromContext returns Timeouts, which were set to context via ContextWithTimeouts.
+func TimeoutsFromContext(ctx context.Context) (Timeouts, bool) {
+	timeouts, err := contextlib.ValueFromContext[Timeouts](ctx, timeoutsContextKey)
+	if err != nil {
+		return Timeouts{}, false
+	}
+
+	return timeouts, true
+}
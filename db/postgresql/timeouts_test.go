@@ -0,0 +1,47 @@
+package postgresql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DKhorkov/libs/db/postgresql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeoutsFromContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("timeouts were set to context", func(t *testing.T) {
+		t.Parallel()
+
+		expected := postgresql.Timeouts{
+			Statement:                time.Second,
+			Lock:                     time.Millisecond,
+			IdleInTransactionSession: time.Minute,
+		}
+
+		ctx := postgresql.ContextWithTimeouts(context.Background(), expected)
+		actual, ok := postgresql.TimeoutsFromContext(ctx)
+		require.True(t, ok)
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("timeouts were not set to context", func(t *testing.T) {
+		t.Parallel()
+
+		actual, ok := postgresql.TimeoutsFromContext(context.Background())
+		require.False(t, ok)
+		assert.True(t, actual.IsZero())
+	})
+}
+
+func TestTimeoutsIsZero(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, postgresql.Timeouts{}.IsZero())
+	assert.False(t, postgresql.Timeouts{Statement: time.Second}.IsZero())
+	assert.False(t, postgresql.Timeouts{Lock: time.Second}.IsZero())
+	assert.False(t, postgresql.Timeouts{IdleInTransactionSession: time.Second}.IsZero())
+}
@@ -1,7 +1,56 @@
 package logging
 
+import (
+	"os"
+	"time"
+)
+
 // Config is a logging config, on base of which logger instance is created.
+// Zero FilePermissions means default permissions 0600.
 type Config struct {
-	Level       Level
-	LogFilePath string
+	Level           Level
+	LogFilePath     string
+	FilePermissions os.FileMode
+	Rotation        RotationConfig
+}
+
+// RotationConfig is a config of log file rotation. Zero config disables rotation.
+type RotationConfig struct {
+	MaxSize        int64
+	Interval       time.Duration
+	MaxBackups     int
+	MaxAge         time.Duration
+	Compress       bool
+	ReopenOnSIGHUP bool
+}
+
+// options converts config to options of RotatingFile.
+func (c RotationConfig) options() []RotationOption {
+	var opts []RotationOption
+
+	if c.MaxSize > 0 {
+		opts = append(opts, WithMaxSize(c.MaxSize))
+	}
+
+	if c.Interval > 0 {
+		opts = append(opts, WithRotationInterval(c.Interval))
+	}
+
+	if c.MaxBackups > 0 {
+		opts = append(opts, WithMaxBackups(c.MaxBackups))
+	}
+
+	if c.MaxAge > 0 {
+		opts = append(opts, WithMaxAge(c.MaxAge))
+	}
+
+	if c.Compress {
+		opts = append(opts, WithCompression())
+	}
+
+	if c.ReopenOnSIGHUP {
+		opts = append(opts, WithReopenOnSIGHUP())
+	}
+
+	return opts
 }
@@ -0,0 +1,91 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/DKhorkov/libs/contextlib"
+	"github.com/DKhorkov/libs/requestid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Keys of attributes, added to records from context.
+const (
+	RequestIDKey = "Request ID"
+	TraceIDKey   = "Trace ID"
+	SpanIDKey    = "Span ID"
+	UserIDKey    = "User ID"
+)
+
+// UserIDContextKey is a context key of user ID, which is set by AuthMiddleware of middlewares/http package.
+const UserIDContextKey = "userID"
+
+// contextHandler is a slog.Handler, which adds request ID, trace ID, span ID and user ID from context to records.
+type contextHandler struct {
+	handler slog.Handler
+}
+
+// NewContextHandler wraps handler, adding request ID, trace ID, span ID and user ID from context to every record.
+// Values, which are absent in context, and attributes, which were already added to record, are skipped.
+func NewContextHandler(handler slog.Handler) slog.Handler {
+	return &contextHandler{handler: handler}
+}
+
+func (h *contextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx == nil {
+		return h.handler.Handle(ctx, record)
+	}
+
+	attrs := make([]slog.Attr, 0, 4)
+
+	if requestID, err := contextlib.ValueFromContext[string](ctx, requestid.Key); err == nil && requestID != "" {
+		attrs = append(attrs, slog.String(RequestIDKey, requestID))
+	}
+
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		attrs = append(
+			attrs,
+			slog.String(TraceIDKey, spanContext.TraceID().String()),
+			slog.String(SpanIDKey, spanContext.SpanID().String()),
+		)
+	}
+
+	if userID, err := contextlib.ValueFromContext[any](ctx, UserIDContextKey); err == nil && userID != nil {
+		attrs = append(attrs, slog.String(UserIDKey, fmt.Sprint(userID)))
+	}
+
+	if len(attrs) == 0 {
+		return h.handler.Handle(ctx, record)
+	}
+
+	// Attributes, added manually, for example by LogErrorContext, are not duplicated:
+	record.Attrs(func(attr slog.Attr) bool {
+		for i := range attrs {
+			if attrs[i].Key == attr.Key {
+				attrs = append(attrs[:i], attrs[i+1:]...)
+
+				break
+			}
+		}
+
+		return len(attrs) > 0
+	})
+
+	record = record.Clone()
+	record.AddAttrs(attrs...)
+
+	return h.handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{handler: h.handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{handler: h.handler.WithGroup(name)}
+}
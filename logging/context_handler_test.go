@@ -0,0 +1,84 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/DKhorkov/libs/contextlib"
+	"github.com/DKhorkov/libs/logging"
+	"github.com/DKhorkov/libs/requestid"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestContextHandler(t *testing.T) {
+	t.Parallel()
+
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+
+	ctx := trace.ContextWithSpanContext(
+		context.Background(),
+		trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}),
+	)
+	ctx = contextlib.WithValue(ctx, requestid.Key, "test-request-id")
+	ctx = contextlib.WithValue(ctx, logging.UserIDContextKey, uint64(42))
+
+	decode := func(t *testing.T, buf *bytes.Buffer) map[string]any {
+		t.Helper()
+
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+
+		return record
+	}
+
+	t.Run("attributes are added from context", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		logger := slog.New(logging.NewContextHandler(slog.NewJSONHandler(&buf, nil)))
+		logger.InfoContext(ctx, "test message")
+
+		record := decode(t, &buf)
+		require.Equal(t, "test-request-id", record[logging.RequestIDKey])
+		require.Equal(t, traceID.String(), record[logging.TraceIDKey])
+		require.Equal(t, spanID.String(), record[logging.SpanIDKey])
+		require.Equal(t, "42", record[logging.UserIDKey])
+	})
+
+	t.Run("attributes are not added without context values", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		logger := slog.New(logging.NewContextHandler(slog.NewJSONHandler(&buf, nil)))
+		logger.InfoContext(context.Background(), "test message")
+
+		record := decode(t, &buf)
+		require.NotContains(t, record, logging.RequestIDKey)
+		require.NotContains(t, record, logging.TraceIDKey)
+		require.NotContains(t, record, logging.UserIDKey)
+	})
+
+	t.Run("manually added request ID is not duplicated", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+
+		logger, _, err := logging.NewLogger(logging.WithWriter(&buf))
+		require.NoError(t, err)
+
+		logging.LogErrorContext(ctx, logger, "test error message", nil)
+
+		require.Equal(t, 1, bytes.Count(buf.Bytes(), []byte(`"Request ID"`)))
+		require.Equal(t, traceID.String(), decode(t, &buf)[logging.TraceIDKey])
+	})
+}
@@ -0,0 +1,178 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Format is a format of log records, written to sink.
+type Format string
+
+// Formats of log records.
+const (
+	// FormatJSON writes each record as JSON object.
+	FormatJSON Format = "json"
+
+	// FormatLogfmt writes each record as key=value pairs, including time, level and message.
+	FormatLogfmt Format = "logfmt"
+
+	// FormatText writes each record as human-readable line with time, level and message,
+	// followed by key=value pairs of attributes.
+	FormatText Format = "text"
+)
+
+const textTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// newFormatHandler creates slog.Handler, which writes records of provided format to writer.
+func newFormatHandler(writer io.Writer, format Format, level slog.Leveler) (slog.Handler, error) {
+	handlerOptions := &slog.HandlerOptions{Level: level}
+
+	switch format {
+	case FormatJSON:
+		return slog.NewJSONHandler(writer, handlerOptions), nil
+	case FormatLogfmt:
+		return slog.NewTextHandler(writer, handlerOptions), nil
+	case FormatText:
+		return &textHandler{writer: writer, level: level, mu: &sync.Mutex{}}, nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q", format)
+	}
+}
+
+// textHandler is a slog.Handler, which writes records in FormatText.
+type textHandler struct {
+	writer io.Writer
+	level  slog.Leveler
+	mu     *sync.Mutex
+
+	// groupPrefix is a prefix of attributes keys, created from groups names.
+	groupPrefix string
+
+	// attrs are preformatted attributes, added via WithAttrs.
+	attrs []byte
+}
+
+func (h *textHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *textHandler) Handle(_ context.Context, record slog.Record) error {
+	buf := make([]byte, 0, 256)
+	if !record.Time.IsZero() {
+		buf = record.Time.AppendFormat(buf, textTimeFormat)
+		buf = append(buf, ' ')
+	}
+
+	buf = fmt.Appendf(buf, "%-5s %s", record.Level.String(), record.Message)
+	buf = append(buf, h.attrs...)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		buf = appendTextAttr(buf, h.groupPrefix, attr)
+
+		return true
+	})
+
+	buf = append(buf, '\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, err := h.writer.Write(buf)
+
+	return err
+}
+
+func (h *textHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handler := *h
+	handler.attrs = append([]byte(nil), h.attrs...)
+
+	for _, attr := range attrs {
+		handler.attrs = appendTextAttr(handler.attrs, h.groupPrefix, attr)
+	}
+
+	return &handler
+}
+
+func (h *textHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	handler := *h
+	handler.groupPrefix = h.groupPrefix + name + "."
+
+	return &handler
+}
+
+// appendTextAttr appends attribute as " key=value" pair. Attributes of groups are flattened with dotted keys.
+func appendTextAttr(buf []byte, prefix string, attr slog.Attr) []byte {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return buf
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			prefix += attr.Key + "."
+		}
+
+		for _, groupAttr := range attr.Value.Group() {
+			buf = appendTextAttr(buf, prefix, groupAttr)
+		}
+
+		return buf
+	}
+
+	buf = append(buf, ' ')
+	buf = append(buf, prefix...)
+	buf = append(buf, attr.Key...)
+	buf = append(buf, '=')
+
+	return appendTextValue(buf, attr.Value)
+}
+
+func appendTextValue(buf []byte, value slog.Value) []byte {
+	var str string
+
+	switch value.Kind() {
+	case slog.KindString:
+		str = value.String()
+	case slog.KindTime:
+		str = value.Time().Format(time.RFC3339Nano)
+	case slog.KindAny:
+		if err, ok := value.Any().(error); ok {
+			str = err.Error()
+		} else {
+			str = value.String()
+		}
+	default:
+		str = value.String()
+	}
+
+	if needsQuoting(str) {
+		return strconv.AppendQuote(buf, str)
+	}
+
+	return append(buf, str...)
+}
+
+// needsQuoting reports, whether value should be quoted to keep key=value pairs parsable.
+func needsQuoting(str string) bool {
+	if str == "" {
+		return true
+	}
+
+	for _, r := range str {
+		if unicode.IsSpace(r) || r == '=' || r == '"' || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,223 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+)
+
+const (
+	// RootLoggerName is a name of logger, created via NewLogger, in LevelController.
+	RootLoggerName = "root"
+
+	// LoggerNameKey is a key of attribute with name of logger, created via Named.
+	LoggerNameKey = "logger"
+)
+
+// LevelController manages levels of logger and its named sub-loggers at runtime. Named sub-logger uses level
+// of root logger, until own level is set.
+type LevelController struct {
+	root      *slog.LevelVar
+	overrides map[string]slog.Level
+	names     map[string]struct{}
+	mu        sync.RWMutex
+
+	// toggledFrom is a level of root logger before toggling to DEBUG via signal.
+	toggledFrom *slog.Level
+}
+
+// NewLevelController creates *LevelController with provided level of root logger.
+func NewLevelController(level Level) *LevelController {
+	root := &slog.LevelVar{}
+	root.Set(slog.Level(level))
+
+	return &LevelController{
+		root:      root,
+		overrides: make(map[string]slog.Level),
+		names:     make(map[string]struct{}),
+	}
+}
+
+// LevelVar returns level of root logger, which can be changed at runtime.
+func (c *LevelController) LevelVar() *slog.LevelVar {
+	return c.root
+}
+
+// Level returns level of logger with provided name.
+func (c *LevelController) Level(name string) Level {
+	return Level(c.leveler(name).Level())
+}
+
+// SetLevel sets level of logger with provided name. Setting level of root logger cancels toggle to DEBUG,
+// so next ToggleDebug switches to DEBUG again instead of restoring level before toggle.
+func (c *LevelController) SetLevel(name string, level Level) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if name == RootLoggerName {
+		c.root.Set(slog.Level(level))
+		c.toggledFrom = nil
+
+		return
+	}
+
+	c.names[name] = struct{}{}
+	c.overrides[name] = slog.Level(level)
+}
+
+// ResetLevel removes own level of named logger, so it uses level of root logger again.
+func (c *LevelController) ResetLevel(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.overrides, name)
+}
+
+// Levels returns levels of root logger and of all named loggers.
+func (c *LevelController) Levels() map[string]Level {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	levels := map[string]Level{RootLoggerName: Level(c.root.Level())}
+	for name := range c.names {
+		level, ok := c.overrides[name]
+		if !ok {
+			level = c.root.Level()
+		}
+
+		levels[name] = Level(level)
+	}
+
+	return levels
+}
+
+// ToggleDebugOnSignal switches level of root logger to DEBUG on SIGUSR1 and back to previous level on next SIGUSR1,
+// until ctx is done. Signals are not supported on Windows, where method does nothing.
+func (c *LevelController) ToggleDebugOnSignal(ctx context.Context) {
+	if len(toggleSignals) == 0 {
+		return
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, toggleSignals...)
+
+	go func() {
+		defer signal.Stop(signals)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-signals:
+				c.ToggleDebug()
+			}
+		}
+	}()
+}
+
+// ToggleDebug switches level of root logger to DEBUG or back to level, which was set before previous toggle.
+func (c *LevelController) ToggleDebug() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.toggledFrom != nil {
+		c.root.Set(*c.toggledFrom)
+		c.toggledFrom = nil
+
+		return
+	}
+
+	level := c.root.Level()
+	c.toggledFrom = &level
+	c.root.Set(slog.LevelDebug)
+}
+
+// leveler returns slog.Leveler of logger with provided name.
+func (c *LevelController) leveler(name string) slog.Leveler {
+	if name == RootLoggerName {
+		return c.root
+	}
+
+	return &namedLevel{controller: c, name: name}
+}
+
+// register adds named logger to controller, so its level is returned by Levels.
+func (c *LevelController) register(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.names[name] = struct{}{}
+}
+
+// namedLevel is a slog.Leveler of named logger, which falls back to root logger level.
+type namedLevel struct {
+	controller *LevelController
+	name       string
+}
+
+func (l *namedLevel) Level() slog.Level {
+	l.controller.mu.RLock()
+	level, ok := l.controller.overrides[l.name]
+	l.controller.mu.RUnlock()
+
+	if ok {
+		return level
+	}
+
+	return l.controller.root.Level()
+}
+
+// levelHandler is a slog.Handler, which filters records by dynamic level of logger.
+type levelHandler struct {
+	handler    slog.Handler
+	level      slog.Leveler
+	controller *LevelController
+	name       string
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() && h.handler.Enabled(ctx, level)
+}
+
+func (h *levelHandler) Handle(ctx context.Context, record slog.Record) error {
+	return h.handler.Handle(ctx, record)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handler := *h
+	handler.handler = h.handler.WithAttrs(attrs)
+
+	return &handler
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	handler := *h
+	handler.handler = h.handler.WithGroup(name)
+
+	return &handler
+}
+
+// Named creates sub-logger with provided name, which is added to records as "logger" attribute.
+// If logger was created via NewLogger with LevelController, sub-logger level can be changed separately.
+// Names of nested sub-loggers are joined with dot.
+func Named(logger *slog.Logger, name string) *slog.Logger {
+	handler, ok := logger.Handler().(*levelHandler)
+	if !ok || handler.controller == nil {
+		return logger.With(LoggerNameKey, name)
+	}
+
+	if handler.name != RootLoggerName {
+		name = handler.name + "." + name
+	}
+
+	handler.controller.register(name)
+
+	return slog.New(&levelHandler{
+		handler:    handler.handler.WithAttrs([]slog.Attr{slog.String(LoggerNameKey, name)}),
+		level:      handler.controller.leveler(name),
+		controller: handler.controller,
+		name:       name,
+	})
+}
@@ -0,0 +1,123 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DKhorkov/libs/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelController(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	controller := logging.NewLevelController(logging.Levels.INFO)
+	logger, _, err := logging.NewLogger(logging.WithWriter(&buf), logging.WithLevelController(controller))
+	require.NoError(t, err)
+
+	db := logging.Named(logger, "db")
+	pool := logging.Named(db, "pool")
+
+	logger.Debug("root debug")
+	db.Debug("db debug")
+
+	controller.SetLevel("db", logging.Levels.DEBUG)
+	db.Debug("db debug enabled")
+	logger.Debug("root debug")
+
+	controller.SetLevel(logging.RootLoggerName, logging.Levels.ERROR)
+	pool.Warn("pool warn")
+
+	controller.ResetLevel("db")
+	db.Debug("db debug")
+	db.Error("db error")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[0], `"msg":"db debug enabled","logger":"db"`)
+	require.Contains(t, lines[1], `"msg":"db error","logger":"db"`)
+
+	require.Equal(
+		t,
+		map[string]logging.Level{
+			logging.RootLoggerName: logging.Levels.ERROR,
+			"db":                   logging.Levels.ERROR,
+			"db.pool":              logging.Levels.ERROR,
+		},
+		controller.Levels(),
+	)
+
+	controller.ToggleDebug()
+	require.Equal(t, logging.Levels.DEBUG, controller.Level(logging.RootLoggerName))
+	controller.ToggleDebug()
+	require.Equal(t, logging.Levels.ERROR, controller.Level(logging.RootLoggerName))
+
+	// Level, set after toggle, is not overwritten by the next toggle:
+	controller.ToggleDebug()
+	controller.SetLevel(logging.RootLoggerName, logging.Levels.WARN)
+	controller.ToggleDebug()
+	require.Equal(t, logging.Levels.DEBUG, controller.Level(logging.RootLoggerName))
+	controller.ToggleDebug()
+	require.Equal(t, logging.Levels.WARN, controller.Level(logging.RootLoggerName))
+}
+
+func TestNamedWithoutController(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logging.Named(logger, "db").Info("test message")
+	require.Contains(t, buf.String(), `"logger":"db"`)
+}
+
+func TestLevelsHandler(t *testing.T) {
+	t.Parallel()
+
+	controller := logging.NewLevelController(logging.Levels.INFO)
+	handler := logging.LevelsHandler(controller)
+
+	serve := func(method, target, body string) (int, map[string]string) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader(body)))
+
+		var levels map[string]string
+		if recorder.Code == http.StatusOK {
+			require.NoError(t, json.NewDecoder(recorder.Body).Decode(&levels))
+		}
+
+		return recorder.Code, levels
+	}
+
+	code, levels := serve(http.MethodGet, "/log/levels", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, map[string]string{"root": "INFO"}, levels)
+
+	code, levels = serve(http.MethodPut, "/log/levels", `{"logger":"db","level":"debug"}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, map[string]string{"root": "INFO", "db": "DEBUG"}, levels)
+
+	code, levels = serve(http.MethodPost, "/log/levels", `{"level":"WARN"}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, map[string]string{"root": "WARN", "db": "DEBUG"}, levels)
+
+	code, levels = serve(http.MethodDelete, "/log/levels?logger=db", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, map[string]string{"root": "WARN", "db": "WARN"}, levels)
+
+	code, _ = serve(http.MethodPut, "/log/levels", `{"level":"verbose"}`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = serve(http.MethodDelete, "/log/levels", "")
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = serve(http.MethodPatch, "/log/levels", "")
+	require.Equal(t, http.StatusMethodNotAllowed, code)
+}
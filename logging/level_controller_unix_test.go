@@ -0,0 +1,30 @@
+//go:build unix
+
+package logging_test
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelControllerToggleDebugOnSignal(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	controller := logging.NewLevelController(logging.Levels.WARN)
+	controller.ToggleDebugOnSignal(ctx)
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR1))
+	require.Eventually(
+		t,
+		func() bool { return controller.Level(logging.RootLoggerName) == logging.Levels.DEBUG },
+		time.Second,
+		time.Millisecond,
+	)
+}
@@ -0,0 +1,70 @@
+package logging
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// levelRequest is a body of request for changing logger level.
+type levelRequest struct {
+	Logger string `json:"logger"`
+	Level  string `json:"level"`
+}
+
+// LevelsHandler creates http.Handler for viewing and changing levels of loggers, managed by controller:
+//   - GET returns levels of all loggers as JSON object, for example {"root":"INFO","db":"DEBUG"};
+//   - PUT or POST with body {"logger":"db","level":"DEBUG"} sets level of logger. Empty logger means root logger;
+//   - DELETE with "logger" query parameter resets own level of named logger.
+//
+// All methods respond with levels of all loggers.
+func LevelsHandler(controller *LevelController) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodPost:
+			var request levelRequest
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+
+				return
+			}
+
+			var level slog.Level
+			if err := level.UnmarshalText([]byte(strings.ToUpper(request.Level))); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+
+				return
+			}
+
+			if request.Logger == "" {
+				request.Logger = RootLoggerName
+			}
+
+			controller.SetLevel(request.Logger, Level(level))
+		case http.MethodDelete:
+			name := r.URL.Query().Get("logger")
+			if name == "" || name == RootLoggerName {
+				http.Error(w, "level of named logger can be reset only", http.StatusBadRequest)
+
+				return
+			}
+
+			controller.ResetLevel(name)
+		default:
+			w.Header().Set("Allow", "GET, PUT, POST, DELETE")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		levels := make(map[string]string)
+		for name, level := range controller.Levels() {
+			levels[name] = slog.Level(level).String()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(levels)
+	})
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package logging
+
+import "os"
+
+// toggleSignals are signals, which toggle DEBUG level of root logger. SIGUSR1 is not supported on this platform.
+var toggleSignals []os.Signal
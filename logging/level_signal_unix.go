@@ -0,0 +1,11 @@
+//go:build unix
+
+package logging
+
+import (
+	"os"
+	"syscall"
+)
+
+// toggleSignals are signals, which toggle DEBUG level of root logger.
+var toggleSignals = []os.Signal{syscall.SIGUSR1}
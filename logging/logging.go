@@ -2,10 +2,13 @@ package logging
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
 	"os"
+	"path/filepath"
 	"runtime"
 	"sync"
 
@@ -14,43 +17,188 @@ import (
 )
 
 const (
-	skipLevel  = 2
-	permission = 0o777
+	skipLevel = 2
+	minLevel  = slog.Level(math.MinInt)
+
+	// legacyFilePermissions are permissions of log file, created via New, kept for backward compatibility.
+	legacyFilePermissions = 0o777
 )
 
 var (
-	instance *slog.Logger
-	once     sync.Once
+	// legacyFiles are log files, opened via New. Every file is opened once and shared by all loggers,
+	// created via New with its path, because New does not let caller close it.
+	legacyFiles   = make(map[string]*os.File)
+	legacyFilesMu sync.Mutex
 )
 
-// New implements as singleton pattern to get Logger instance, created once for whole app:.
+// New creates Logger, which writes JSON records to stdout and to file with provided path.
+// If file can not be opened, records are written to stdout only. Each call creates new logger with its own level,
+// but file is opened only once per path and is shared by all loggers, writing to it.
+// Log file is created with 0777 permissions for backward compatibility, NewFromConfig should be used
+// for configuring them.
 func New(logLevel Level, logFilePath string) Logger {
-	var logWriter io.Writer
-
-	if logFile, err := os.OpenFile(
-		logFilePath,
-		os.O_RDWR|os.O_CREATE|os.O_APPEND,
-		permission,
-	); err != nil {
+	file, err := openLegacyFile(logFilePath)
+	if err != nil {
 		fmt.Printf("Failed to open log file %s: %s\n", logFilePath, err)
 
-		logWriter = os.Stdout
+		logger, _, _ := NewLogger(WithLevel(logLevel), WithStdout())
+
+		return logger
+	}
+
+	// Closer of logger is not returned, because shared file is not owned by single logger:
+	logger, _, _ := NewLogger(WithLevel(logLevel), WithStdout(), WithWriter(file))
+
+	return logger
+}
+
+// openLegacyFile returns log file with provided path, opening it on first call.
+func openLegacyFile(path string) (*os.File, error) {
+	if absolutePath, err := filepath.Abs(path); err == nil {
+		path = absolutePath
+	}
+
+	legacyFilesMu.Lock()
+	defer legacyFilesMu.Unlock()
+
+	if file, ok := legacyFiles[path]; ok {
+		return file, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, legacyFilePermissions)
+	if err != nil {
+		return nil, err
+	}
+
+	legacyFiles[path] = file
+
+	return file, nil
+}
+
+// NewFromConfig creates *slog.Logger, which writes JSON records to stdout and to file of config, rotating it
+// according to rotation config. Returned io.Closer closes file like the one, returned by NewLogger.
+func NewFromConfig(config Config) (*slog.Logger, io.Closer, error) {
+	filePermissions := config.FilePermissions
+	if filePermissions == 0 {
+		filePermissions = defaultFilePermissions
+	}
+
+	return NewLogger(
+		WithLevel(config.Level),
+		WithStdout(),
+		WithFile(config.LogFilePath, SinkRotation(config.Rotation.options()...)),
+		WithFilePermissions(filePermissions),
+	)
+}
+
+// NewLogger creates new *slog.Logger with provided options. Records are written to all sinks and custom handlers,
+// added via options. If no sinks and handlers are added, records are written to stdout.
+// Records, logged with context, are enriched with request ID, trace ID, span ID and user ID from it.
+// Returned io.Closer closes files of sinks and stops their rotation, so it should be called, when logger
+// is not used anymore.
+func NewLogger(opts ...Option) (*slog.Logger, io.Closer, error) {
+	options := newOptions()
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(options.sinks) == 0 && len(options.handlers) == 0 {
+		options.sinks = append(options.sinks, &sinkOptions{writer: os.Stdout})
+	}
+
+	var files closers
+
+	handlers := make([]slog.Handler, 0, len(options.sinks)+len(options.handlers))
+	for _, sink := range options.sinks {
+		handler, file, err := newSinkHandler(sink, options)
+		if err != nil {
+			// Files of already created sinks are not used anymore:
+			return nil, nil, errors.Join(err, files.Close())
+		}
+
+		if file != nil {
+			files = append(files, file)
+		}
+
+		handlers = append(handlers, handler)
+	}
+
+	var level slog.Leveler = slog.Level(options.level)
+	if options.levelController != nil {
+		level = options.levelController.LevelVar()
+	}
+
+	var handler slog.Handler = &levelHandler{
+		handler:    NewContextHandler(newMultiHandler(append(handlers, options.handlers...)...)),
+		level:      level,
+		controller: options.levelController,
+		name:       RootLoggerName,
+	}
+
+	if len(options.attrs) > 0 {
+		handler = handler.WithAttrs(options.attrs)
+	}
+
+	return slog.New(handler), files, nil
+}
+
+// closers closes all its io.Closer and joins their errors.
+type closers []io.Closer
+
+func (c closers) Close() error {
+	errs := make([]error, 0, len(c))
+	for _, closer := range c {
+		errs = append(errs, closer.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// newSinkHandler creates handler for sink, using logger options as defaults. Sink without own level
+// accepts all records, because they are filtered by logger level before. Returns opened file of sink
+// or nil, if sink writes to provided writer.
+func newSinkHandler(sink *sinkOptions, options *options) (slog.Handler, io.Closer, error) {
+	level, format := Level(minLevel), options.format
+	if sink.level != nil {
+		level = *sink.level
+	}
+
+	if sink.format != nil {
+		format = *sink.format
+	}
+
+	if sink.filePath == "" {
+		handler, err := newFormatHandler(sink.writer, format, slog.Level(level))
+
+		return handler, nil, err
+	}
+
+	var (
+		file io.WriteCloser
+		err  error
+	)
+
+	if len(sink.rotationOpts) > 0 {
+		rotationOpts := append([]RotationOption{WithRotatingFilePermissions(options.filePermissions)}, sink.rotationOpts...)
+		file, err = NewRotatingFile(sink.filePath, rotationOpts...)
 	} else {
-		logWriter = io.MultiWriter(os.Stdout, logFile)
+		file, err = os.OpenFile(sink.filePath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, options.filePermissions)
+	}
+
+	if err != nil {
+		return nil, nil, err
 	}
 
-	once.Do(func() {
-		instance = slog.New(
-			slog.NewJSONHandler(
-				logWriter,
-				&slog.HandlerOptions{
-					Level: slog.Level(logLevel),
-				},
-			),
-		)
-	})
+	handler, err := newFormatHandler(file, format, slog.Level(level))
+	if err != nil {
+		_ = file.Close()
+
+		return nil, nil, err
+	}
 
-	return instance
+	return handler, file, nil
 }
 
 // GetLogTraceback return a string with info about filename, function name and line
@@ -78,7 +226,7 @@ func LogErrorContext(ctx context.Context, logger Logger, msg string, err error,
 	}
 
 	args = append(args, []any{
-		"Request ID",
+		RequestIDKey,
 		requestID,
 		"Traceback",
 		GetLogTraceback(skipLevel),
@@ -102,7 +250,7 @@ func LogInfoContext(ctx context.Context, logger Logger, msg string, args ...any)
 	}
 
 	args = append(args, []any{
-		"Request ID",
+		RequestIDKey,
 		requestID,
 		"Traceback",
 		GetLogTraceback(skipLevel),
@@ -115,6 +263,50 @@ func LogInfoContext(ctx context.Context, logger Logger, msg string, args ...any)
 	)
 }
 
+// LogWarnContext uses provided logger to save warning message info and context.
+// Context is used to get request ID and connect it with warning.
+func LogWarnContext(ctx context.Context, logger Logger, msg string, args ...any) {
+	requestID, err := contextlib.ValueFromContext[string](ctx, requestid.Key)
+	if err != nil {
+		requestID = ""
+	}
+
+	args = append(args, []any{
+		RequestIDKey,
+		requestID,
+		"Traceback",
+		GetLogTraceback(skipLevel),
+	}...)
+
+	logger.WarnContext(
+		ctx,
+		msg,
+		args...,
+	)
+}
+
+// LogDebugContext uses provided logger to save debug message info and context.
+// Context is used to get request ID and connect it with message.
+func LogDebugContext(ctx context.Context, logger Logger, msg string, args ...any) {
+	requestID, err := contextlib.ValueFromContext[string](ctx, requestid.Key)
+	if err != nil {
+		requestID = ""
+	}
+
+	args = append(args, []any{
+		RequestIDKey,
+		requestID,
+		"Traceback",
+		GetLogTraceback(skipLevel),
+	}...)
+
+	logger.DebugContext(
+		ctx,
+		msg,
+		args...,
+	)
+}
+
 // LogError logs error with message info, using provided logger.
 func LogError(logger Logger, msg string, err error, args ...any) {
 	args = append(args, []any{
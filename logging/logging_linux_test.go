@@ -0,0 +1,49 @@
+//go:build linux
+
+package logging_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DKhorkov/libs/logging"
+	"github.com/stretchr/testify/require"
+)
+
+// openDescriptors returns number of file descriptors of process, which refer to provided path.
+func openDescriptors(t *testing.T, path string) int {
+	t.Helper()
+
+	entries, err := os.ReadDir("/proc/self/fd")
+	require.NoError(t, err)
+
+	count := 0
+
+	for _, entry := range entries {
+		target, err := os.Readlink(filepath.Join("/proc/self/fd", entry.Name()))
+		if err == nil && target == path {
+			count++
+		}
+	}
+
+	return count
+}
+
+func TestNewSharesLogFile(t *testing.T) {
+	t.Parallel()
+
+	logFilePath := filepath.Join(t.TempDir(), "test.log")
+
+	// Повторные вызовы не открывают файл заново
+	for range 10 {
+		logging.New(logging.Levels.INFO, logFilePath).Info("message")
+	}
+
+	require.Equal(t, 1, openDescriptors(t, logFilePath))
+
+	content, err := os.ReadFile(logFilePath)
+	require.NoError(t, err)
+	require.Equal(t, 10, strings.Count(string(content), "\n"))
+}
@@ -29,9 +29,11 @@ func TestNew(t *testing.T) {
 	_, ok := logger.(*slog.Logger)
 	require.True(t, ok)
 
-	// Проверяем singleton: повторный вызов должен вернуть тот же экземпляр
+	// Проверяем, что повторный вызов создаёт новый экземпляр со своим уровнем
 	logger2 := logging.New(logging.Levels.DEBUG, logFilePath)
-	require.Equal(t, logger, logger2)
+	require.NotSame(t, logger, logger2)
+	require.False(t, logger.(*slog.Logger).Enabled(context.Background(), slog.LevelDebug))
+	require.True(t, logger2.(*slog.Logger).Enabled(context.Background(), slog.LevelDebug))
 
 	// Проверяем, что файл создан
 	_, err := os.Stat(logFilePath)
@@ -191,3 +193,85 @@ func TestLogInfo(t *testing.T) {
 	require.Contains(t, logOutput, `"msg":"test info message"`)
 	require.Contains(t, logOutput, `"Traceback"`)
 }
+
+func TestLogWarnContext(t *testing.T) {
+	t.Parallel()
+
+	ctx := contextlib.WithValue(context.Background(), requestid.Key, "test-request-id")
+
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	logging.LogWarnContext(ctx, logger, "test warn message")
+
+	logOutput := buf.String()
+	require.Contains(t, logOutput, `"level":"WARN"`)
+	require.Contains(t, logOutput, `"msg":"test warn message"`)
+	require.Contains(t, logOutput, `"Request ID":"test-request-id"`)
+	require.Contains(t, logOutput, `"Traceback"`)
+}
+
+func TestLogDebugContext(t *testing.T) {
+	t.Parallel()
+
+	ctx := contextlib.WithValue(context.Background(), requestid.Key, "test-request-id")
+
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	logging.LogDebugContext(ctx, logger, "test debug message")
+
+	logOutput := buf.String()
+	require.Contains(t, logOutput, `"level":"DEBUG"`)
+	require.Contains(t, logOutput, `"msg":"test debug message"`)
+	require.Contains(t, logOutput, `"Request ID":"test-request-id"`)
+	require.Contains(t, logOutput, `"Traceback"`)
+}
+
+func TestNewFromConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	logger, closer, err := logging.NewFromConfig(
+		logging.Config{
+			Level:       logging.Levels.INFO,
+			LogFilePath: filepath.Join(dir, "app.log"),
+			Rotation:    logging.RotationConfig{MaxSize: 1, MaxBackups: 1},
+		},
+	)
+	require.NoError(t, err)
+
+	logger.Info("first")
+	logger.Info("second")
+	logger.Info("third")
+	require.NoError(t, closer.Close())
+
+	// Файл ротируется перед каждой записью, но хранится только одна резервная копия
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	// По умолчанию файл доступен только владельцу
+	info, err := os.Stat(filepath.Join(dir, "app.log"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestNewFromConfigFilePermissions(t *testing.T) {
+	t.Parallel()
+
+	logFilePath := filepath.Join(t.TempDir(), "app.log")
+
+	_, closer, err := logging.NewFromConfig(
+		logging.Config{Level: logging.Levels.INFO, LogFilePath: logFilePath, FilePermissions: 0o640},
+	)
+	require.NoError(t, err)
+	require.NoError(t, closer.Close())
+
+	info, err := os.Stat(logFilePath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+}
@@ -0,0 +1,185 @@
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"os"
+)
+
+const defaultFilePermissions = 0o600
+
+// Attributes keys, added via WithService.
+const (
+	ServiceKey     = "service"
+	VersionKey     = "version"
+	EnvironmentKey = "environment"
+)
+
+// newOptions creates *options with default values.
+func newOptions() *options {
+	return &options{
+		level:           Levels.INFO,
+		format:          FormatJSON,
+		filePermissions: defaultFilePermissions,
+	}
+}
+
+// options represents options for logger configuration.
+type options struct {
+	level           Level
+	format          Format
+	filePermissions os.FileMode
+	sinks           []*sinkOptions
+	handlers        []slog.Handler
+	attrs           []slog.Attr
+	levelController *LevelController
+}
+
+// Option represents golang functional option pattern func for logger configuration.
+type Option func(options *options) error
+
+// sinkOptions represents options for configuration of single log output.
+type sinkOptions struct {
+	writer       io.Writer
+	filePath     string
+	level        *Level
+	format       *Format
+	rotationOpts []RotationOption
+}
+
+// SinkOption represents golang functional option pattern func for configuration of single log output.
+type SinkOption func(options *sinkOptions) error
+
+// WithLevel sets minimal level of records of logger. Default level is INFO.
+// Level is ignored, if logger level is managed by LevelController.
+func WithLevel(level Level) Option {
+	return func(options *options) error {
+		options.level = level
+
+		return nil
+	}
+}
+
+// WithLevelController sets controller, which manages level of logger and of its named sub-loggers at runtime.
+func WithLevelController(controller *LevelController) Option {
+	return func(options *options) error {
+		options.levelController = controller
+
+		return nil
+	}
+}
+
+// WithFormat sets format of records for sinks without own format. Default format is FormatJSON.
+func WithFormat(format Format) Option {
+	return func(options *options) error {
+		options.format = format
+
+		return nil
+	}
+}
+
+// WithFilePermissions sets permissions for log files, created by logger. Default permissions are 0600.
+func WithFilePermissions(permissions os.FileMode) Option {
+	return func(options *options) error {
+		options.filePermissions = permissions
+
+		return nil
+	}
+}
+
+// WithStdout adds sink, which writes records to stdout.
+func WithStdout(opts ...SinkOption) Option {
+	return WithWriter(os.Stdout, opts...)
+}
+
+// WithStderr adds sink, which writes records to stderr.
+func WithStderr(opts ...SinkOption) Option {
+	return WithWriter(os.Stderr, opts...)
+}
+
+// WithFile adds sink, which appends records to file with provided path.
+func WithFile(path string, opts ...SinkOption) Option {
+	return withSink(&sinkOptions{filePath: path}, opts)
+}
+
+// WithWriter adds sink, which writes records to provided writer.
+func WithWriter(writer io.Writer, opts ...SinkOption) Option {
+	return withSink(&sinkOptions{writer: writer}, opts)
+}
+
+// WithHandler adds custom handler, to which records, enabled for logger level, are passed along with sinks.
+func WithHandler(handler slog.Handler) Option {
+	return func(options *options) error {
+		options.handlers = append(options.handlers, handler)
+
+		return nil
+	}
+}
+
+// WithAttrs adds static attributes to every record of logger.
+func WithAttrs(attrs ...slog.Attr) Option {
+	return func(options *options) error {
+		options.attrs = append(options.attrs, attrs...)
+
+		return nil
+	}
+}
+
+// WithService adds service name, version and environment attributes to every record of logger.
+// Empty values are skipped.
+func WithService(name, version, environment string) Option {
+	return func(options *options) error {
+		for _, attr := range []slog.Attr{
+			slog.String(ServiceKey, name),
+			slog.String(VersionKey, version),
+			slog.String(EnvironmentKey, environment),
+		} {
+			if attr.Value.String() != "" {
+				options.attrs = append(options.attrs, attr)
+			}
+		}
+
+		return nil
+	}
+}
+
+// SinkLevel sets minimal level of records for sink. Records are filtered by logger level before.
+func SinkLevel(level Level) SinkOption {
+	return func(options *sinkOptions) error {
+		options.level = &level
+
+		return nil
+	}
+}
+
+// SinkFormat sets format of records for sink.
+func SinkFormat(format Format) SinkOption {
+	return func(options *sinkOptions) error {
+		options.format = &format
+
+		return nil
+	}
+}
+
+// SinkRotation enables rotation of file, added via WithFile, with provided options.
+func SinkRotation(opts ...RotationOption) SinkOption {
+	return func(options *sinkOptions) error {
+		options.rotationOpts = append(options.rotationOpts, opts...)
+
+		return nil
+	}
+}
+
+func withSink(sink *sinkOptions, opts []SinkOption) Option {
+	return func(options *options) error {
+		for _, opt := range opts {
+			if err := opt(sink); err != nil {
+				return err
+			}
+		}
+
+		options.sinks = append(options.sinks, sink)
+
+		return nil
+	}
+}
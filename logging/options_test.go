@@ -0,0 +1,123 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DKhorkov/libs/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLoggerFormats(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		format   logging.Format
+		expected []string
+	}{
+		{
+			name:     "json",
+			format:   logging.FormatJSON,
+			expected: []string{`"level":"INFO"`, `"msg":"test message"`, `"key":"some value"`, `"group":{"id":1}`},
+		},
+		{
+			name:     "logfmt",
+			format:   logging.FormatLogfmt,
+			expected: []string{"level=INFO", `msg="test message"`, `key="some value"`, "group.id=1"},
+		},
+		{
+			name:     "text",
+			format:   logging.FormatText,
+			expected: []string{"INFO  test message", `key="some value"`, "group.id=1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			logger, _, err := logging.NewLogger(logging.WithWriter(&buf), logging.WithFormat(tc.format))
+			require.NoError(t, err)
+
+			logger.Info("test message", "key", "some value", slog.Group("group", "id", 1))
+
+			for _, expected := range tc.expected {
+				require.Contains(t, buf.String(), expected)
+			}
+		})
+	}
+}
+
+func TestNewLoggerUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := logging.NewLogger(logging.WithStdout(logging.SinkFormat("xml")))
+	require.Error(t, err)
+}
+
+func TestNewLoggerSinks(t *testing.T) {
+	t.Parallel()
+
+	var debugBuf, errorBuf bytes.Buffer
+
+	logFilePath := filepath.Join(t.TempDir(), "test.log")
+
+	logger, closer, err := logging.NewLogger(
+		logging.WithLevel(logging.Levels.DEBUG),
+		logging.WithWriter(&debugBuf, logging.SinkFormat(logging.FormatText)),
+		logging.WithWriter(&errorBuf, logging.SinkLevel(logging.Levels.ERROR)),
+		logging.WithFile(logFilePath, logging.SinkLevel(logging.Levels.WARN)),
+		logging.WithFilePermissions(0o640),
+	)
+	require.NoError(t, err)
+
+	logger.Debug("debug message")
+	logger.Warn("warn message")
+	logger.Error("error message", "error", errors.New("test error"))
+
+	require.Equal(t, 3, strings.Count(debugBuf.String(), "\n"))
+	require.Contains(t, debugBuf.String(), `error="test error"`)
+	require.Equal(t, 1, strings.Count(errorBuf.String(), "\n"))
+	require.Contains(t, errorBuf.String(), `"msg":"error message"`)
+
+	content, err := os.ReadFile(logFilePath)
+	require.NoError(t, err)
+	require.Equal(t, 2, strings.Count(string(content), "\n"))
+
+	info, err := os.Stat(logFilePath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+
+	// Closer closes file of sink:
+	require.NoError(t, closer.Close())
+	require.ErrorIs(t, closer.Close(), os.ErrClosed)
+}
+
+func TestNewLoggerAttrsAndHandler(t *testing.T) {
+	t.Parallel()
+
+	var buf, handlerBuf bytes.Buffer
+
+	logger, _, err := logging.NewLogger(
+		logging.WithWriter(&buf),
+		logging.WithHandler(slog.NewTextHandler(&handlerBuf, nil)),
+		logging.WithService("orders", "1.2.3", ""),
+		logging.WithAttrs(slog.String("region", "eu")),
+	)
+	require.NoError(t, err)
+
+	logger.InfoContext(context.Background(), "test message")
+
+	require.Contains(t, buf.String(), `"service":"orders","version":"1.2.3","region":"eu"`)
+	require.NotContains(t, buf.String(), logging.EnvironmentKey)
+	require.Contains(t, handlerBuf.String(), "service=orders version=1.2.3 region=eu")
+}
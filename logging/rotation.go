@@ -0,0 +1,358 @@
+package logging
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	backupTimeFormat   = "20060102T150405.000000000"
+	compressedFileExt  = ".gz"
+	compressingFileExt = ".gz.tmp"
+)
+
+// RotatingFile is an io.WriteCloser, which writes to file and rotates it by size and time.
+// Rotated files are renamed to "<name>-<timestamp><ext>" in the same directory.
+type RotatingFile struct {
+	path     string
+	options  *rotationOptions
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	closed   bool
+	mu       sync.Mutex
+
+	// cleanupMu allows only one cleanup of rotated files at a time.
+	cleanupMu sync.Mutex
+	cleanups  sync.WaitGroup
+
+	signals chan os.Signal
+	done    chan struct{}
+}
+
+// NewRotatingFile opens or creates file with provided path for appending and rotates it according to options.
+func NewRotatingFile(path string, opts ...RotationOption) (*RotatingFile, error) {
+	options := newRotationOptions()
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, err
+		}
+	}
+
+	file := &RotatingFile{
+		path:    path,
+		options: options,
+		done:    make(chan struct{}),
+	}
+
+	if err := file.open(); err != nil {
+		return nil, err
+	}
+
+	if options.reopenOnSIGHUP {
+		file.signals = make(chan os.Signal, 1)
+		signal.Notify(file.signals, syscall.SIGHUP)
+
+		go file.reopenOnSignal()
+	}
+
+	return file, nil
+}
+
+// Write writes data to file, rotating it before, if rotation by size or time is required.
+func (f *RotatingFile) Write(data []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.ensureOpen(); err != nil {
+		return 0, err
+	}
+
+	if f.shouldRotate(len(data)) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(data)
+	f.size += int64(n)
+
+	return n, err
+}
+
+// Rotate renames current file to backup and opens new file.
+func (f *RotatingFile) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.ensureOpen(); err != nil {
+		return err
+	}
+
+	return f.rotate()
+}
+
+// Reopen closes and opens file with the same path. It is used after external tool, for example logrotate,
+// has moved file.
+func (f *RotatingFile) Reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return os.ErrClosed
+	}
+
+	if f.file != nil {
+		err := f.file.Close()
+		f.file = nil
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return f.open()
+}
+
+// Close stops reopening on signals, waits for cleanup of rotated files and closes file.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return os.ErrClosed
+	}
+
+	f.closed = true
+
+	if f.signals != nil {
+		signal.Stop(f.signals)
+		close(f.done)
+	}
+
+	f.cleanups.Wait()
+
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+
+	return err
+}
+
+// ensureOpen returns os.ErrClosed for closed file and opens file again, if previous rotation or reopening
+// has failed, so writes are not lost forever after temporary errors.
+func (f *RotatingFile) ensureOpen() error {
+	if f.closed {
+		return os.ErrClosed
+	}
+
+	if f.file != nil {
+		return nil
+	}
+
+	return f.open()
+}
+
+func (f *RotatingFile) shouldRotate(writeSize int) bool {
+	if f.options.maxSize > 0 && f.size > 0 && f.size+int64(writeSize) > f.options.maxSize {
+		return true
+	}
+
+	return f.options.interval > 0 && time.Since(f.openedAt) >= f.options.interval
+}
+
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, f.options.filePermissions)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = time.Now()
+
+	return nil
+}
+
+// rotate closes file, renames it to backup and opens new file. If file can not be renamed, it is opened again
+// for appending. If file can not be opened, it is opened by the next call of RotatingFile methods.
+func (f *RotatingFile) rotate() error {
+	err := f.file.Close()
+	f.file = nil
+
+	if err != nil {
+		return err
+	}
+
+	if err = os.Rename(f.path, f.backupPath(time.Now())); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Join(err, f.open())
+	}
+
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	if f.options.compress || f.options.maxBackups > 0 || f.options.maxAge > 0 {
+		f.cleanups.Add(1)
+
+		go func() {
+			defer f.cleanups.Done()
+
+			f.cleanup()
+		}()
+	}
+
+	return nil
+}
+
+// backupPath returns path of rotated file, created at provided time.
+func (f *RotatingFile) backupPath(rotatedAt time.Time) string {
+	dir, prefix, ext := f.nameParts()
+
+	return filepath.Join(dir, prefix+rotatedAt.Format(backupTimeFormat)+ext)
+}
+
+// nameParts returns directory, prefix of rotated files names and extension of file.
+func (f *RotatingFile) nameParts() (string, string, string) {
+	dir, name := filepath.Split(f.path)
+	ext := filepath.Ext(name)
+
+	return dir, strings.TrimSuffix(name, ext) + "-", ext
+}
+
+// backup is a rotated file.
+type backup struct {
+	path      string
+	rotatedAt time.Time
+}
+
+// backups returns rotated files, sorted from newest to oldest.
+func (f *RotatingFile) backups() ([]backup, error) {
+	dir, prefix, ext := f.nameParts()
+	if dir == "" {
+		dir = "."
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []backup
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		timestamp := strings.TrimPrefix(strings.TrimSuffix(name, compressedFileExt), prefix)
+		if !strings.HasSuffix(timestamp, ext) {
+			continue
+		}
+
+		rotatedAt, err := time.ParseInLocation(backupTimeFormat, strings.TrimSuffix(timestamp, ext), time.Local)
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, backup{path: filepath.Join(dir, name), rotatedAt: rotatedAt})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].rotatedAt.After(backups[j].rotatedAt)
+	})
+
+	return backups, nil
+}
+
+// cleanup removes rotated files, exceeding backups count or age, and compresses remaining ones.
+// Errors are ignored, because files are checked again after next rotation.
+func (f *RotatingFile) cleanup() {
+	f.cleanupMu.Lock()
+	defer f.cleanupMu.Unlock()
+
+	backups, err := f.backups()
+	if err != nil {
+		return
+	}
+
+	for i, rotated := range backups {
+		expired := f.options.maxAge > 0 && time.Since(rotated.rotatedAt) > f.options.maxAge
+		if (f.options.maxBackups > 0 && i >= f.options.maxBackups) || expired {
+			_ = os.Remove(rotated.path)
+
+			continue
+		}
+
+		if f.options.compress && !strings.HasSuffix(rotated.path, compressedFileExt) {
+			_ = compressFile(rotated.path, f.options.filePermissions)
+		}
+	}
+}
+
+// compressFile replaces file with its gzip-compressed copy.
+func compressFile(path string, permissions os.FileMode) (err error) {
+	source, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = errors.Join(err, source.Close())
+	}()
+
+	tmpPath := path + compressingFileExt
+
+	destination, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, permissions)
+	if err != nil {
+		return err
+	}
+
+	writer := gzip.NewWriter(destination)
+	if _, err = io.Copy(writer, source); err == nil {
+		err = writer.Close()
+	}
+
+	if err = errors.Join(err, destination.Close()); err != nil {
+		_ = os.Remove(tmpPath)
+
+		return err
+	}
+
+	if err = os.Rename(tmpPath, path+compressedFileExt); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
+
+func (f *RotatingFile) reopenOnSignal() {
+	for {
+		select {
+		case <-f.done:
+			return
+		case <-f.signals:
+			_ = f.Reopen()
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package logging
+
+import (
+	"os"
+	"time"
+)
+
+// rotationOptions represents options for RotatingFile configuration.
+type rotationOptions struct {
+	// maxSize is a size of file in bytes, after reaching which file is rotated. 0 disables rotation by size.
+	maxSize int64
+
+	// interval is a period, after which file is rotated. 0 disables rotation by time.
+	interval time.Duration
+
+	// maxBackups is a maximum number of rotated files to keep. 0 keeps all files.
+	maxBackups int
+
+	// maxAge is a maximum age of rotated files to keep. 0 keeps all files.
+	maxAge time.Duration
+
+	compress        bool
+	reopenOnSIGHUP  bool
+	filePermissions os.FileMode
+}
+
+func newRotationOptions() *rotationOptions {
+	return &rotationOptions{
+		filePermissions: defaultFilePermissions,
+	}
+}
+
+// RotationOption represents golang functional option pattern func for RotatingFile configuration.
+type RotationOption func(options *rotationOptions) error
+
+// WithMaxSize sets size of file in bytes, after reaching which file is rotated.
+func WithMaxSize(size int64) RotationOption {
+	return func(options *rotationOptions) error {
+		options.maxSize = size
+
+		return nil
+	}
+}
+
+// WithRotationInterval sets period, after which file is rotated.
+func WithRotationInterval(interval time.Duration) RotationOption {
+	return func(options *rotationOptions) error {
+		options.interval = interval
+
+		return nil
+	}
+}
+
+// WithMaxBackups sets maximum number of rotated files to keep. Older files are removed.
+func WithMaxBackups(count int) RotationOption {
+	return func(options *rotationOptions) error {
+		options.maxBackups = count
+
+		return nil
+	}
+}
+
+// WithMaxAge sets maximum age of rotated files to keep. Older files are removed.
+func WithMaxAge(age time.Duration) RotationOption {
+	return func(options *rotationOptions) error {
+		options.maxAge = age
+
+		return nil
+	}
+}
+
+// WithCompression enables gzip compression of rotated files.
+func WithCompression() RotationOption {
+	return func(options *rotationOptions) error {
+		options.compress = true
+
+		return nil
+	}
+}
+
+// WithReopenOnSIGHUP enables reopening of file on SIGHUP for compatibility with external logrotate.
+func WithReopenOnSIGHUP() RotationOption {
+	return func(options *rotationOptions) error {
+		options.reopenOnSIGHUP = true
+
+		return nil
+	}
+}
+
+// WithRotatingFilePermissions sets permissions for created files. Default permissions are 0600.
+func WithRotatingFilePermissions(permissions os.FileMode) RotationOption {
+	return func(options *rotationOptions) error {
+		options.filePermissions = permissions
+
+		return nil
+	}
+}
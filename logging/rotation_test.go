@@ -0,0 +1,162 @@
+package logging_test
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func backupFiles(t *testing.T, dir string) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	var backups []string
+
+	for _, entry := range entries {
+		if entry.Name() != "app.log" {
+			backups = append(backups, entry.Name())
+		}
+	}
+
+	return backups
+}
+
+func TestRotatingFileBySize(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	file, err := logging.NewRotatingFile(path, logging.WithMaxSize(10), logging.WithMaxBackups(2))
+	require.NoError(t, err)
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err = file.Write([]byte(line))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, file.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "fourth\n", string(content))
+
+	backups := backupFiles(t, dir)
+	require.Len(t, backups, 2)
+
+	for _, backup := range backups {
+		require.True(t, strings.HasPrefix(backup, "app-"))
+		require.True(t, strings.HasSuffix(backup, ".log"))
+	}
+
+	_, err = file.Write([]byte("closed\n"))
+	require.ErrorIs(t, err, os.ErrClosed)
+}
+
+func TestRotatingFileByTime(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	file, err := logging.NewRotatingFile(filepath.Join(dir, "app.log"), logging.WithRotationInterval(time.Millisecond))
+	require.NoError(t, err)
+
+	_, err = file.Write([]byte("first\n"))
+	require.NoError(t, err)
+
+	time.Sleep(2 * time.Millisecond)
+
+	_, err = file.Write([]byte("second\n"))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	require.NotEmpty(t, backupFiles(t, dir))
+}
+
+func TestRotatingFileCompression(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	file, err := logging.NewRotatingFile(filepath.Join(dir, "app.log"), logging.WithCompression())
+	require.NoError(t, err)
+
+	_, err = file.Write([]byte("rotated\n"))
+	require.NoError(t, err)
+	require.NoError(t, file.Rotate())
+	require.NoError(t, file.Close())
+
+	backups := backupFiles(t, dir)
+	require.Len(t, backups, 1)
+	require.True(t, strings.HasSuffix(backups[0], ".log.gz"))
+
+	compressed, err := os.Open(filepath.Join(dir, backups[0]))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, compressed.Close())
+	}()
+
+	reader, err := gzip.NewReader(compressed)
+	require.NoError(t, err)
+
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, "rotated\n", string(content))
+}
+
+func TestRotatingFileRecoversAfterFailedReopen(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	file, err := logging.NewRotatingFile(path)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, file.Close())
+	}()
+
+	// File can not be opened, while directory occupies its path:
+	require.NoError(t, os.Rename(path, path+".1"))
+	require.NoError(t, os.Mkdir(path, 0o700))
+	require.Error(t, file.Reopen())
+
+	_, err = file.Write([]byte("lost\n"))
+	require.Error(t, err)
+	require.NotErrorIs(t, err, os.ErrClosed)
+
+	require.NoError(t, os.Remove(path))
+
+	_, err = file.Write([]byte("after recovery\n"))
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "after recovery\n", string(content))
+}
+
+func TestNewLoggerWithRotation(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	logger, _, err := logging.NewLogger(
+		logging.WithFile(filepath.Join(dir, "app.log"), logging.SinkRotation(logging.WithMaxSize(1))),
+	)
+	require.NoError(t, err)
+
+	logger.Info("first")
+	logger.Info("second")
+
+	require.Len(t, backupFiles(t, dir), 1)
+}
@@ -0,0 +1,51 @@
+//go:build unix
+
+package logging_test
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/DKhorkov/libs/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFileReopenOnSIGHUP(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	file, err := logging.NewRotatingFile(path, logging.WithReopenOnSIGHUP())
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, file.Close())
+	}()
+
+	_, err = file.Write([]byte("before logrotate\n"))
+	require.NoError(t, err)
+
+	// Emulates logrotate, which moves file and sends SIGHUP:
+	require.NoError(t, os.Rename(path, path+".1"))
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+
+	require.Eventually(
+		t,
+		func() bool {
+			_, statErr := os.Stat(path)
+
+			return statErr == nil
+		},
+		time.Second,
+		time.Millisecond,
+	)
+
+	_, err = file.Write([]byte("after logrotate\n"))
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "after logrotate\n", string(content))
+}
@@ -0,0 +1,307 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	natsbroker "github.com/nats-io/nats.go"
+)
+
+// AsyncPublisher is a NATS publisher, which buffers messages in memory and publishes them in background.
+// If spill file is set via WithSpillFile, messages, which do not fit buffer or can not be published
+// due to lost connection, are written to file and published after reconnect. Order of messages is kept,
+// unless they were spilled.
+type AsyncPublisher struct {
+	connection *natsbroker.Conn
+	options    *publisherOptions
+	spill      *spillFile
+	queue      chan *natsbroker.Msg
+	replay     chan struct{}
+	done       chan struct{}
+	pending    atomic.Int64
+	closed     bool
+	mu         sync.RWMutex
+}
+
+// NewAsyncPublisher creates *AsyncPublisher with provided options and starts publishing in background.
+func NewAsyncPublisher(url string, opts ...PublisherOption) (*AsyncPublisher, error) {
+	options := newPublisherOptions()
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, err
+		}
+	}
+
+	publisher := &AsyncPublisher{
+		options: options,
+		queue:   make(chan *natsbroker.Msg, options.bufferSize),
+		replay:  make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+
+	if options.spillFilePath != "" {
+		spill, err := openSpillFile(options.spillFilePath)
+		if err != nil {
+			return nil, err
+		}
+
+		publisher.spill = spill
+	}
+
+	connection, err := natsbroker.Connect(url, options.natsOpts...)
+	if err != nil {
+		if publisher.spill != nil {
+			return nil, errors.Join(err, publisher.spill.close())
+		}
+
+		return nil, err
+	}
+
+	if options.metricsEnabled {
+		instrumentConnection(connection)
+	}
+
+	publisher.connection = connection
+
+	reconnectHandler := connection.Opts.ReconnectedCB
+	connection.SetReconnectHandler(func(conn *natsbroker.Conn) {
+		publisher.replaySpill()
+
+		if reconnectHandler != nil {
+			reconnectHandler(conn)
+		}
+	})
+
+	publisher.replaySpill()
+
+	go publisher.run()
+
+	return publisher, nil
+}
+
+// Publish buffers message for publishing to provided subject.
+func (p *AsyncPublisher) Publish(subject string, data []byte) error {
+	message := natsbroker.NewMsg(subject)
+	message.Data = data
+
+	return p.enqueue(message)
+}
+
+// PublishContext buffers message with provided headers for publishing to provided subject.
+// Request ID and W3C trace context of ctx are added to headers.
+func (p *AsyncPublisher) PublishContext(
+	ctx context.Context,
+	subject string,
+	data []byte,
+	headers natsbroker.Header,
+) error {
+	return p.enqueue(newMessage(ctx, subject, data, headers))
+}
+
+// Flush waits, until all buffered and spilled messages are published and are processed by NATS server.
+// Returns *UnflushedMessagesError, if ctx is done earlier.
+func (p *AsyncPublisher) Flush(ctx context.Context) error {
+	p.mu.RLock()
+	closed := p.closed
+	p.mu.RUnlock()
+
+	if closed {
+		return &PublisherClosedError{}
+	}
+
+	p.replaySpill()
+
+	if !waitFor(ctx, func() bool { return p.unflushed() == 0 }) {
+		return &UnflushedMessagesError{BaseErr: ctx.Err(), Unflushed: p.unflushed()}
+	}
+
+	return p.flushConnection(ctx)
+}
+
+// Close publishes all buffered messages and closes NATS connection.
+func (p *AsyncPublisher) Close() error {
+	return p.Shutdown(context.Background())
+}
+
+// Shutdown stops accepting new messages, publishes buffered and spilled messages and closes NATS connection.
+// If ctx is done earlier, remaining messages are spilled or are passed to PublishErrorHandler
+// and *UnflushedMessagesError is returned.
+func (p *AsyncPublisher) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+
+		return &PublisherClosedError{}
+	}
+
+	p.closed = true
+	close(p.queue)
+	p.mu.Unlock()
+
+	var err error
+
+	select {
+	case <-p.done:
+		// Worker has stopped, so spilled messages can be replayed without concurrent publishing:
+		p.replaySpillNow()
+		err = p.flushConnection(ctx)
+	case <-ctx.Done():
+	}
+
+	unflushed := p.unflushed()
+
+	p.connection.Close()
+	<-p.done
+
+	if p.spill != nil {
+		err = errors.Join(err, p.spill.close())
+	}
+
+	if ctx.Err() != nil && unflushed > 0 {
+		return &UnflushedMessagesError{BaseErr: ctx.Err(), Unflushed: unflushed}
+	}
+
+	return err
+}
+
+// enqueue adds message to buffer or spills it, if buffer is full.
+func (p *AsyncPublisher) enqueue(message *natsbroker.Msg) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return &PublisherClosedError{}
+	}
+
+	p.pending.Add(1)
+
+	select {
+	case p.queue <- message:
+		return nil
+	default:
+		p.pending.Add(-1)
+	}
+
+	if p.spill == nil {
+		return &BufferFullError{}
+	}
+
+	return p.spill.write(message)
+}
+
+// run publishes buffered messages until buffer is closed.
+func (p *AsyncPublisher) run() {
+	defer close(p.done)
+
+	for {
+		select {
+		case message, ok := <-p.queue:
+			if !ok {
+				return
+			}
+
+			p.publish(message)
+			p.pending.Add(-1)
+		case <-p.replay:
+			p.replaySpillNow()
+		}
+	}
+}
+
+// publish sends message to NATS or spills it, if connection is lost.
+func (p *AsyncPublisher) publish(message *natsbroker.Msg) {
+	if p.spill != nil && !p.connection.IsConnected() {
+		p.spillMessage(message, natsbroker.ErrConnectionReconnecting)
+
+		return
+	}
+
+	err := p.publishNow(message)
+	if err == nil {
+		return
+	}
+
+	if p.spill != nil {
+		p.spillMessage(message, err)
+
+		return
+	}
+
+	p.handleError(message, err)
+}
+
+func (p *AsyncPublisher) publishNow(message *natsbroker.Msg) error {
+	err := p.connection.PublishMsg(message)
+	if p.options.metricsEnabled {
+		observePublish(p.options.subjectNormalizer, message.Subject, len(message.Data), err)
+	}
+
+	return err
+}
+
+func (p *AsyncPublisher) spillMessage(message *natsbroker.Msg, cause error) {
+	if err := p.spill.write(message); err != nil {
+		p.handleError(message, errors.Join(cause, err))
+	}
+}
+
+// replaySpill asks worker to publish spilled messages.
+func (p *AsyncPublisher) replaySpill() {
+	if p.spill == nil || p.spill.len() == 0 {
+		return
+	}
+
+	select {
+	case p.replay <- struct{}{}:
+	default:
+	}
+}
+
+// replaySpillNow publishes spilled messages, while connection is established.
+func (p *AsyncPublisher) replaySpillNow() {
+	if p.spill == nil || p.spill.len() == 0 {
+		return
+	}
+
+	err := p.spill.replay(func(message *natsbroker.Msg) error {
+		if !p.connection.IsConnected() {
+			return natsbroker.ErrConnectionReconnecting
+		}
+
+		return p.publishNow(message)
+	})
+	if err != nil {
+		p.handleError(nil, err)
+	}
+}
+
+// unflushed returns number of buffered and spilled messages.
+func (p *AsyncPublisher) unflushed() int {
+	unflushed := int(p.pending.Load())
+	if p.spill != nil {
+		unflushed += p.spill.len()
+	}
+
+	return unflushed
+}
+
+// flushConnection waits, until NATS server processes published messages.
+func (p *AsyncPublisher) flushConnection(ctx context.Context) error {
+	if !p.connection.IsConnected() {
+		return nil
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		return p.connection.Flush()
+	}
+
+	return p.connection.FlushWithContext(ctx)
+}
+
+func (p *AsyncPublisher) handleError(message *natsbroker.Msg, err error) {
+	if p.options.publishErrorHandler != nil {
+		p.options.publishErrorHandler(message, err)
+	}
+}
@@ -0,0 +1,69 @@
+//go:build integration
+
+package nats
+
+import (
+	"context"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	natsbroker "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsyncPublisher(t *testing.T) {
+	const (
+		asyncSubject  = "test.async"
+		messagesCount = 100
+	)
+
+	var received atomic.Int64
+
+	consumer, err := NewConsumer(
+		url,
+		asyncSubject,
+		WithMessageChannelBufferSize(messagesCount),
+		WithHandler(func(context.Context, *natsbroker.Msg) error {
+			received.Add(1)
+
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, consumer.Run())
+
+	defer func() {
+		require.NoError(t, consumer.Stop())
+	}()
+
+	publisher, err := NewAsyncPublisher(
+		url,
+		WithBufferSize(10),
+		WithSpillFile(filepath.Join(t.TempDir(), "spill.jsonl")),
+		WithPublishErrorHandler(func(_ *natsbroker.Msg, err error) {
+			t.Errorf("unexpected publish error: %v", err)
+		}),
+	)
+	require.NoError(t, err)
+
+	for range messagesCount {
+		require.NoError(t, publisher.PublishContext(context.Background(), asyncSubject, []byte("message"), nil))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, publisher.Flush(ctx))
+	require.Zero(t, publisher.unflushed())
+	require.NoError(t, publisher.Shutdown(ctx))
+	require.IsType(t, &PublisherClosedError{}, publisher.Publish(asyncSubject, []byte("message")))
+
+	require.Eventually(
+		t,
+		func() bool { return received.Load() == messagesCount },
+		time.Second,
+		10*time.Millisecond,
+	)
+}
@@ -0,0 +1,70 @@
+package nats
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Content types of supported codecs.
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/protobuf"
+)
+
+// ContentTypeHeader is a header, which contains content type of message data.
+const ContentTypeHeader = "Content-Type"
+
+// Codec encodes and decodes message data.
+type Codec interface {
+	ContentType() string
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// JSONCodec encodes and decodes message data as JSON.
+type JSONCodec struct{}
+
+// ContentType returns "application/json".
+func (JSONCodec) ContentType() string {
+	return ContentTypeJSON
+}
+
+// Marshal encodes v as JSON.
+func (JSONCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes JSON data to v.
+func (JSONCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// ProtobufCodec encodes and decodes message data as protobuf. Values should implement proto.Message.
+type ProtobufCodec struct{}
+
+// ContentType returns "application/protobuf".
+func (ProtobufCodec) ContentType() string {
+	return ContentTypeProtobuf
+}
+
+// Marshal encodes v as protobuf.
+func (ProtobufCodec) Marshal(v any) ([]byte, error) {
+	message, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("%T does not implement proto.Message", v)
+	}
+
+	return proto.Marshal(message)
+}
+
+// Unmarshal decodes protobuf data to v.
+func (ProtobufCodec) Unmarshal(data []byte, v any) error {
+	message, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("%T does not implement proto.Message", v)
+	}
+
+	return proto.Unmarshal(data, message)
+}
@@ -0,0 +1,46 @@
+package nats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestJSONCodec(t *testing.T) {
+	t.Parallel()
+
+	type payload struct {
+		Name string `json:"name"`
+	}
+
+	codec := JSONCodec{}
+	require.Equal(t, ContentTypeJSON, codec.ContentType())
+
+	data, err := codec.Marshal(payload{Name: "test"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"test"}`, string(data))
+
+	var decoded payload
+	require.NoError(t, codec.Unmarshal(data, &decoded))
+	require.Equal(t, "test", decoded.Name)
+}
+
+func TestProtobufCodec(t *testing.T) {
+	t.Parallel()
+
+	codec := ProtobufCodec{}
+	require.Equal(t, ContentTypeProtobuf, codec.ContentType())
+
+	data, err := codec.Marshal(wrapperspb.String("test"))
+	require.NoError(t, err)
+
+	decoded := &wrapperspb.StringValue{}
+	require.NoError(t, codec.Unmarshal(data, decoded))
+	require.True(t, proto.Equal(wrapperspb.String("test"), decoded))
+
+	_, err = codec.Marshal("not a proto message")
+	require.Error(t, err)
+	require.Error(t, codec.Unmarshal(data, new(string)))
+}
@@ -1,24 +1,56 @@
 package nats
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/DKhorkov/libs/tracing"
 	natsbroker "github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // CommonConsumer is a base consumer for processing NATS messages.
 type CommonConsumer struct {
 	connection         *natsbroker.Conn
-	subscription       *natsbroker.Subscription
+	subscriptions      []*natsbroker.Subscription
 	messageChannel     chan *natsbroker.Msg
 	goroutinesPoolSize int
-	messageHandler     func(message *natsbroker.Msg)
-	isRunning          bool
-	isStopped          bool
+	handler            MessageHandler
+	retryPolicy        RetryPolicy
+	deadLetterSubject  string
+	errorHandler       func(connection *natsbroker.Conn, subscription *natsbroker.Subscription, err error)
+	traceProvider      tracing.Provider
+	metricsEnabled     bool
+	bufferSize         int
+	reportedBuffered   atomic.Int64
+	state              consumerState
+	mu                 sync.Mutex
+	ctx                context.Context //nolint:containedctx // context is canceled, when shutdown deadline exceeded
+	cancel             context.CancelFunc
+	inFlight           atomic.Int64
 	wg                 *sync.WaitGroup
 }
 
-// NewConsumer creates *CommonConsumer with provided options.
+// consumerState represents lifecycle state of consumer.
+type consumerState int
+
+const (
+	consumerStateCreated consumerState = iota
+	consumerStateRunning
+	consumerStateStopped
+)
+
+// drainPollInterval is an interval of checking, whether subscriptions and connection were drained.
+const drainPollInterval = 10 * time.Millisecond
+
+// NewConsumer creates *CommonConsumer with provided options. Consumer subscribes to provided subject and to
+// subjects, set via WithSubjects and WithSubjectHandler. Provided subject can be empty, if other subjects are set.
 func NewConsumer(
 	url string,
 	subject string,
@@ -32,6 +64,106 @@ func NewConsumer(
 		}
 	}
 
+	subjects := consumerSubjects(subject, options.subjects)
+	if len(subjects) == 0 {
+		return nil, &InvalidSubjectError{Message: "consumer has no subjects"}
+	}
+
+	if options.router != nil {
+		options.handler = routeWithFallback(options.router, options.handler)
+	}
+
+	if options.deduplicationStore != nil {
+		options.handler = Deduplicate(options.deduplicationStore, options.deduplicationTTL, options.handler)
+	}
+
+	connection, err := connect(url, options)
+	if err != nil {
+		return nil, err
+	}
+
+	consumer, err := newCommonConsumer(connection, subjects, options.queueGroup, options)
+	if err != nil {
+		connection.Close()
+
+		return nil, err
+	}
+
+	return consumer, nil
+}
+
+// consumerSubjects returns unique non-empty subjects for consumer subscriptions. Subjects, covered by wildcard
+// of other subject, are dropped, so each message is received via single subscription and is processed once.
+func consumerSubjects(subject string, additional []string) []string {
+	unique := make([]string, 0, len(additional)+1)
+	seen := make(map[string]struct{}, len(additional)+1)
+
+	for _, candidate := range append([]string{subject}, additional...) {
+		if _, ok := seen[candidate]; ok || candidate == "" {
+			continue
+		}
+
+		seen[candidate] = struct{}{}
+		unique = append(unique, candidate)
+	}
+
+	subjects := make([]string, 0, len(unique))
+
+	for _, candidate := range unique {
+		covered := false
+
+		for _, other := range unique {
+			if other != candidate && subjectCovers(other, candidate) {
+				covered = true
+
+				break
+			}
+		}
+
+		if !covered {
+			subjects = append(subjects, candidate)
+		}
+	}
+
+	return subjects
+}
+
+// subjectCovers reports, whether every subject, matching provided subject pattern, also matches provided pattern.
+func subjectCovers(pattern, subject string) bool {
+	patternTokens := strings.Split(pattern, subjectSeparator)
+	subjectTokens := strings.Split(subject, subjectSeparator)
+
+	for i, token := range patternTokens {
+		if token == fullWildcard {
+			return len(subjectTokens) > i
+		}
+
+		if i >= len(subjectTokens) || subjectTokens[i] == fullWildcard {
+			return false
+		}
+
+		if token != singleTokenWildcard && token != subjectTokens[i] {
+			return false
+		}
+	}
+
+	return len(patternTokens) == len(subjectTokens)
+}
+
+// routeWithFallback creates MessageHandler, which routes messages via router and passes messages
+// without matching route to fallback handler.
+func routeWithFallback(router *SubjectRouter, fallback MessageHandler) MessageHandler {
+	return func(ctx context.Context, message *natsbroker.Msg) error {
+		if handler, ok := router.Match(message.Subject); ok {
+			return handler(ctx, message)
+		}
+
+		return fallback(ctx, message)
+	}
+}
+
+// connect connects to NATS and sets connection handlers from provided options.
+func connect(url string, options *consumerOptions) (*natsbroker.Conn, error) {
 	connection, err := natsbroker.Connect(url, options.natsOpts...)
 	if err != nil {
 		return nil, err
@@ -41,18 +173,78 @@ func NewConsumer(
 	connection.SetDisconnectErrHandler(options.disconnectErrorHandler)
 	connection.SetClosedHandler(options.closeHandler)
 
+	if options.metricsEnabled {
+		instrumentConnection(connection)
+	}
+
+	return connection, nil
+}
+
+// newCommonConsumer subscribes to provided subjects via provided connection and creates *CommonConsumer,
+// which processes messages of all subscriptions in common goroutines pool. If queue is not empty,
+// subscriptions join provided queue group.
+func newCommonConsumer(
+	connection *natsbroker.Conn,
+	subjects []string,
+	queue string,
+	options *consumerOptions,
+) (*CommonConsumer, error) {
 	messageChannel := make(chan *natsbroker.Msg, options.messageChannelBufferSize)
+	subscriptions := make([]*natsbroker.Subscription, 0, len(subjects))
+
+	for _, subject := range subjects {
+		var (
+			subscription *natsbroker.Subscription
+			err          error
+		)
+
+		if queue == "" {
+			subscription, err = connection.ChanSubscribe(subject, messageChannel)
+		} else {
+			subscription, err = connection.ChanQueueSubscribe(subject, queue, messageChannel)
+		}
+
+		if err != nil {
+			for _, created := range subscriptions {
+				_ = created.Unsubscribe()
+			}
+
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	// Subscriptions are registered on server after flush, so messages, published after consumer creation, are received:
+	if err := connection.Flush(); err != nil {
+		for _, created := range subscriptions {
+			_ = created.Unsubscribe()
+		}
 
-	subscription, err := connection.ChanSubscribe(subject, messageChannel)
-	if err != nil {
 		return nil, err
 	}
 
+	if options.metricsEnabled {
+		bufferSize.
+			With(prometheus.Labels{connectionLabel: connection.Opts.Name}).
+			Add(float64(options.messageChannelBufferSize))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
 	return &CommonConsumer{
+		ctx:                ctx,
+		cancel:             cancel,
 		connection:         connection,
-		subscription:       subscription,
+		subscriptions:      subscriptions,
 		messageChannel:     messageChannel,
-		messageHandler:     options.messageHandler,
+		handler:            options.handler,
+		retryPolicy:        options.retryPolicy,
+		deadLetterSubject:  options.deadLetterSubject,
+		errorHandler:       options.errorHandler,
+		traceProvider:      options.traceProvider,
+		metricsEnabled:     options.metricsEnabled,
+		bufferSize:         options.messageChannelBufferSize,
 		goroutinesPoolSize: options.goroutinesPoolSize,
 		wg:                 new(sync.WaitGroup),
 	}, nil
@@ -60,8 +252,14 @@ func NewConsumer(
 
 // Run starts goroutines for NATS messages processing.
 func (c *CommonConsumer) Run() error {
-	if c.isRunning {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch c.state {
+	case consumerStateRunning:
 		return &ConsumerAlreadyRunningError{}
+	case consumerStateStopped:
+		return &ConsumerAlreadyStoppedError{}
 	}
 
 	c.wg.Add(c.goroutinesPoolSize)
@@ -71,31 +269,246 @@ func (c *CommonConsumer) Run() error {
 			defer c.wg.Done()
 
 			for msg := range c.messageChannel {
-				c.messageHandler(msg)
+				// Messages, which were not processed before shutdown deadline, are abandoned:
+				if c.ctx.Err() != nil {
+					continue
+				}
+
+				c.inFlight.Add(1)
+				c.process(c.ctx, msg)
+				c.inFlight.Add(-1)
 			}
 		}()
 	}
 
-	c.isRunning = true
+	c.state = consumerStateRunning
 
 	return nil
 }
 
-// Stop stops launched goroutines, which processes NATS messages.
+// Stop stops receiving messages and waits for already received messages to be processed without timeout.
 func (c *CommonConsumer) Stop() error {
-	if c.isStopped {
+	return c.Shutdown(context.Background())
+}
+
+// Shutdown gracefully stops consumer. Subscriptions are drained, so no new messages are received,
+// and already received messages are processed until ctx is done. If ctx is done earlier, context of handlers
+// is canceled, messages, which were not processed yet, are abandoned and *AbandonedMessagesError
+// with their number is returned. Connection is drained and closed afterward.
+// Shutdown is safe to call concurrently with Run and other Shutdown calls.
+func (c *CommonConsumer) Shutdown(ctx context.Context) error {
+	c.mu.Lock()
+	if c.state == consumerStateStopped {
+		c.mu.Unlock()
+
 		return &ConsumerAlreadyStoppedError{}
 	}
 
-	if err := c.subscription.Unsubscribe(); err != nil {
-		return err
+	wasRunning := c.state == consumerStateRunning
+	c.state = consumerStateStopped
+	c.mu.Unlock()
+
+	defer c.cancel()
+
+	for _, subscription := range c.subscriptions {
+		if err := subscription.Drain(); err != nil {
+			return err
+		}
+	}
+
+	drained := waitFor(ctx, func() bool {
+		for _, subscription := range c.subscriptions {
+			if subscription.IsValid() {
+				return false
+			}
+		}
+
+		return true
+	})
+
+	if !drained {
+		// NATS can not deliver messages to closed channel, so subscriptions are closed explicitly:
+		for _, subscription := range c.subscriptions {
+			_ = subscription.Unsubscribe()
+		}
 	}
 
 	close(c.messageChannel)
-	c.wg.Wait()
 
+	var abandoned int64
+
+	if wasRunning {
+		abandoned = c.waitForWorkers(ctx)
+	} else {
+		abandoned = int64(len(c.messageChannel))
+	}
+
+	if err := c.connection.Drain(); err != nil {
+		c.connection.Close()
+	}
+
+	waitFor(ctx, c.connection.IsClosed)
 	c.connection.Close()
-	c.isStopped = true
+
+	if c.metricsEnabled {
+		c.observeBuffered(0)
+		bufferSize.With(prometheus.Labels{connectionLabel: c.connection.Opts.Name}).Sub(float64(c.bufferSize))
+	}
+
+	if abandoned > 0 {
+		return &AbandonedMessagesError{
+			Message:   fmt.Sprintf("%d messages were abandoned during consumer shutdown", abandoned),
+			BaseErr:   ctx.Err(),
+			Abandoned: int(abandoned),
+		}
+	}
 
 	return nil
 }
+
+// waitForWorkers waits for processing goroutines to finish until ctx is done. Returns number of messages,
+// which were abandoned.
+func (c *CommonConsumer) waitForWorkers(ctx context.Context) int64 {
+	done := make(chan struct{})
+
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return 0
+	case <-ctx.Done():
+	}
+
+	abandoned := c.inFlight.Load() + int64(len(c.messageChannel))
+	c.cancel()
+
+	return abandoned
+}
+
+// waitFor checks condition periodically until it is true or ctx is done. Returns false, if ctx was done earlier.
+func waitFor(ctx context.Context, condition func() bool) bool {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for !condition() {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+
+	return true
+}
+
+// observeBuffered records number of messages in consumer message channel. Gauge is shared by consumers
+// with the same connection name, so only difference with previously recorded number is added.
+func (c *CommonConsumer) observeBuffered(buffered int) {
+	previous := c.reportedBuffered.Swap(int64(buffered))
+	bufferedMessages.
+		With(prometheus.Labels{connectionLabel: c.connection.Opts.Name}).
+		Add(float64(int64(buffered) - previous))
+}
+
+// process handles message, retrying failed attempts according to RetryPolicy. If all attempts failed,
+// error is passed to error handler and message is published to dead-letter subject, if it was configured.
+// Request ID and trace context from message headers are passed to handler via ctx.
+func (c *CommonConsumer) process(ctx context.Context, msg *natsbroker.Msg) {
+	if c.metricsEnabled {
+		consumedMessages.With(prometheus.Labels{subjectLabel: subscriptionSubject(msg.Sub)}).Inc()
+		c.observeBuffered(len(c.messageChannel))
+	}
+
+	ctx = ContextFromHeaders(ctx, msg.Header)
+
+	var span trace.Span
+	if c.traceProvider != nil {
+		ctx, span = c.traceProvider.Span(
+			ctx,
+			"nats.consume "+msg.Subject,
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				attribute.String("messaging.system", "nats"),
+				attribute.String("messaging.destination.name", msg.Subject),
+			),
+		)
+		defer span.End()
+	}
+
+	attempts, err := c.handleWithRetries(ctx, msg)
+	if err == nil {
+		return
+	}
+
+	if span != nil {
+		span.SetStatus(tracing.StatusError, err.Error())
+	}
+
+	c.errorHandler(
+		c.connection,
+		msg.Sub,
+		&MessageProcessingError{
+			Message: fmt.Sprintf(
+				"processing of message from subject %q failed after %d attempts",
+				msg.Subject,
+				attempts,
+			),
+			BaseErr: err,
+		},
+	)
+
+	if c.deadLetterSubject == "" {
+		return
+	}
+
+	deadLetter := newDeadLetterMessage(c.deadLetterSubject, msg, err, attempts)
+	if publishErr := c.connection.PublishMsg(deadLetter); publishErr != nil {
+		c.errorHandler(c.connection, msg.Sub, publishErr)
+	}
+}
+
+// handleWithRetries calls handler until it succeeds, attempts are over or ctx is done.
+// Returns number of made attempts and error of the last one.
+func (c *CommonConsumer) handleWithRetries(ctx context.Context, msg *natsbroker.Msg) (int, error) {
+	maxAttempts := max(c.retryPolicy.MaxAttempts, 1)
+
+	var (
+		attempts int
+		err      error
+	)
+
+	for attempts < maxAttempts {
+		if attempts > 0 && !sleep(ctx, c.retryPolicy.delay(attempts+1)) {
+			break
+		}
+
+		attempts++
+
+		if err = c.handle(ctx, msg); err == nil {
+			return attempts, nil
+		}
+	}
+
+	return attempts, err
+}
+
+// handle calls handler, converting its panic to HandlerPanicError, so single message can not crash
+// the whole processing goroutine.
+func (c *CommonConsumer) handle(ctx context.Context, msg *natsbroker.Msg) (err error) {
+	startedAt := time.Now()
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = &HandlerPanicError{BaseErr: fmt.Errorf("%v", recovered)}
+		}
+
+		if c.metricsEnabled {
+			observeHandler(msg, time.Since(startedAt), err)
+		}
+	}()
+
+	return c.handler(ctx, msg)
+}
@@ -1,8 +1,11 @@
 package nats
 
 import (
+	"context"
 	"fmt"
+	"time"
 
+	"github.com/DKhorkov/libs/tracing"
 	natsbroker "github.com/nats-io/nats.go"
 )
 
@@ -36,7 +39,8 @@ func newConsumerOptions() *consumerOptions {
 	return &consumerOptions{
 		messageChannelBufferSize: defaultMessageChannelBufferSize,
 		goroutinesPoolSize:       defaultGoroutinesPoolSize,
-		messageHandler:           defaultMessageHandler,
+		handler:                  wrapMessageHandler(defaultMessageHandler),
+		retryPolicy:              RetryPolicy{MaxAttempts: 1},
 		errorHandler:             defaultErrorHandler,
 		disconnectErrorHandler:   defaultDisconnectErrorHandler,
 		closeHandler:             defaultCloseHandler,
@@ -47,13 +51,35 @@ func newConsumerOptions() *consumerOptions {
 type consumerOptions struct {
 	messageChannelBufferSize int
 	goroutinesPoolSize       int
-	messageHandler           func(message *natsbroker.Msg)
+	handler                  MessageHandler
+	retryPolicy              RetryPolicy
+	deadLetterSubject        string
+	queueGroup               string
+	subjects                 []string
+	router                   *SubjectRouter
+	traceProvider            tracing.Provider
+	metricsEnabled           bool
+	deduplicationStore       DeduplicationStore
+	deduplicationTTL         time.Duration
 	errorHandler             func(connection *natsbroker.Conn, subscription *natsbroker.Subscription, err error)
 	disconnectErrorHandler   func(connection *natsbroker.Conn, err error)
 	closeHandler             func(connection *natsbroker.Conn)
 	natsOpts                 []natsbroker.Option
 }
 
+// MessageHandler processes NATS message. Returned error means, that message processing failed
+// and should be retried according to RetryPolicy.
+type MessageHandler func(ctx context.Context, message *natsbroker.Msg) error
+
+// wrapMessageHandler converts handler without error to MessageHandler.
+func wrapMessageHandler(handler func(message *natsbroker.Msg)) MessageHandler {
+	return func(_ context.Context, message *natsbroker.Msg) error {
+		handler(message)
+
+		return nil
+	}
+}
+
 // ConsumerOption represents golang functional option pattern func for Consumer configuration.
 type ConsumerOption func(options *consumerOptions) error
 
@@ -78,7 +104,111 @@ func WithGoroutinesPoolSize(size int) ConsumerOption {
 // WithMessageHandler sets handler for received message.
 func WithMessageHandler(handler func(message *natsbroker.Msg)) ConsumerOption {
 	return func(options *consumerOptions) error {
-		options.messageHandler = handler
+		options.handler = wrapMessageHandler(handler)
+
+		return nil
+	}
+}
+
+// WithHandler sets handler for received message, which can report processing failure. Failed messages are
+// retried according to RetryPolicy and are published to dead-letter subject, if all attempts failed.
+func WithHandler(handler MessageHandler) ConsumerOption {
+	return func(options *consumerOptions) error {
+		options.handler = handler
+
+		return nil
+	}
+}
+
+// WithRetryPolicy sets policy for retries of failed message processing. By default, messages are not retried.
+func WithRetryPolicy(policy RetryPolicy) ConsumerOption {
+	return func(options *consumerOptions) error {
+		options.retryPolicy = policy
+
+		return nil
+	}
+}
+
+// WithDeadLetterSubject sets subject, where messages are published, if all processing attempts failed.
+// Dead-letter message contains data and headers of original message and failure details in
+// Nats-Dlq-* headers.
+func WithDeadLetterSubject(subject string) ConsumerOption {
+	return func(options *consumerOptions) error {
+		options.deadLetterSubject = subject
+
+		return nil
+	}
+}
+
+// WithQueueGroup sets queue group for consumer subscriptions. Each message is processed only by one consumer
+// of the group, so replicas of application do not process the same message several times.
+func WithQueueGroup(queue string) ConsumerOption {
+	return func(options *consumerOptions) error {
+		options.queueGroup = queue
+
+		return nil
+	}
+}
+
+// WithSubjects sets additional subjects for consumer subscriptions. Subjects can contain wildcards.
+// Messages of all subjects are processed by consumer message handler. Subjects, covered by wildcard of other
+// consumer subject, are not subscribed separately, so each message is processed once.
+func WithSubjects(subjects ...string) ConsumerOption {
+	return func(options *consumerOptions) error {
+		options.subjects = append(options.subjects, subjects...)
+
+		return nil
+	}
+}
+
+// WithSubjectHandler subscribes consumer to provided subject, which can contain wildcards, and sets handler
+// for messages with matching subjects. Messages are routed via SubjectRouter, so the most specific handler
+// is used, if subjects overlap. Messages without matching handler are processed by consumer message handler.
+func WithSubjectHandler(subject string, handler MessageHandler) ConsumerOption {
+	return func(options *consumerOptions) error {
+		if options.router == nil {
+			options.router = NewSubjectRouter()
+		}
+
+		if err := options.router.Handle(subject, handler); err != nil {
+			return err
+		}
+
+		options.subjects = append(options.subjects, subject)
+
+		return nil
+	}
+}
+
+// WithTracing sets tracing.Provider for creating consumer span for every message. Span continues trace,
+// which context was propagated in message headers by PublishContext.
+func WithTracing(provider tracing.Provider) ConsumerOption {
+	return func(options *consumerOptions) error {
+		options.traceProvider = provider
+
+		return nil
+	}
+}
+
+// WithMetrics enables Prometheus metrics of consumed messages, handlers, message channel buffer
+// and connection state. Metrics are registered in default Prometheus registry. Connection name,
+// set via WithNatsOptions(natsbroker.Name(...)), is used as a label of buffer and connection metrics.
+// Buffer metrics of consumers with the same connection name are summed.
+func WithMetrics() ConsumerOption {
+	return func(options *consumerOptions) error {
+		options.metricsEnabled = true
+
+		return nil
+	}
+}
+
+// WithDeduplication enables skipping of messages, which IDs were already recorded in provided store.
+// ID is taken from Nats-Msg-Id header or from event envelope. IDs are stored for provided ttl,
+// which should exceed redelivery and duplicates windows. Default ttl is 24 hours.
+func WithDeduplication(store DeduplicationStore, ttl time.Duration) ConsumerOption {
+	return func(options *consumerOptions) error {
+		options.deduplicationStore = store
+		options.deduplicationTTL = ttl
 
 		return nil
 	}
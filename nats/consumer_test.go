@@ -3,6 +3,8 @@
 package nats
 
 import (
+	"context"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -48,7 +50,7 @@ func TestConsumer_Run(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		consumer.isRunning = true
+		consumer.state = consumerStateRunning
 		err = consumer.Run()
 		require.Error(t, err)
 		assert.IsType(t, &ConsumerAlreadyRunningError{}, err)
@@ -175,7 +177,7 @@ func TestConsumer_Stop(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		consumer.isStopped = true
+		consumer.state = consumerStateStopped
 		err = consumer.Stop()
 		require.Error(t, err)
 		assert.IsType(t, &ConsumerAlreadyStoppedError{}, err)
@@ -228,3 +230,150 @@ func TestConsumer_Stop(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestConsumer_QueueGroup(t *testing.T) {
+	const (
+		queueSubject  = "test.queue.orders"
+		messagesCount = 10
+	)
+
+	var (
+		processed       atomic.Int64
+		routedProcessed atomic.Int64
+	)
+
+	consumers := make([]*CommonConsumer, 0, 2)
+
+	for range 2 {
+		consumer, err := NewConsumer(
+			url,
+			"",
+			WithQueueGroup("test-queue"),
+			WithMessageChannelBufferSize(2*messagesCount),
+			WithSubjectHandler("test.queue.*", func(context.Context, *natsbroker.Msg) error {
+				routedProcessed.Add(1)
+
+				return nil
+			}),
+			WithSubjects("test.queue.>"),
+			WithHandler(func(context.Context, *natsbroker.Msg) error {
+				processed.Add(1)
+
+				return nil
+			}),
+		)
+		require.NoError(t, err)
+		require.NoError(t, consumer.Run())
+
+		consumers = append(consumers, consumer)
+	}
+
+	publisher, err := NewPublisher(url)
+	require.NoError(t, err)
+
+	for range messagesCount {
+		require.NoError(t, publisher.Publish(queueSubject, []byte("order")))
+		require.NoError(t, publisher.Publish(queueSubject+".v2", []byte("order")))
+	}
+
+	require.NoError(t, publisher.Close())
+
+	// Overlapping subjects share single subscription, so each message is processed once by most specific handler:
+	require.Eventually(
+		t,
+		func() bool {
+			return routedProcessed.Load() == messagesCount && processed.Load() == messagesCount
+		},
+		time.Second,
+		10*time.Millisecond,
+	)
+
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, int64(messagesCount), routedProcessed.Load())
+	require.Equal(t, int64(messagesCount), processed.Load())
+
+	for _, consumer := range consumers {
+		require.NoError(t, consumer.Stop())
+	}
+}
+
+func TestConsumer_Shutdown(t *testing.T) {
+	const shutdownSubject = "test.shutdown"
+
+	t.Run("in-flight messages are processed before deadline", func(t *testing.T) {
+		var processed atomic.Int64
+
+		consumer, err := NewConsumer(
+			url,
+			shutdownSubject,
+			WithMessageChannelBufferSize(10),
+			WithHandler(func(context.Context, *natsbroker.Msg) error {
+				time.Sleep(10 * time.Millisecond)
+				processed.Add(1)
+
+				return nil
+			}),
+		)
+		require.NoError(t, err)
+		require.NoError(t, consumer.Run())
+
+		publisher, err := NewPublisher(url)
+		require.NoError(t, err)
+
+		for range 5 {
+			require.NoError(t, publisher.Publish(shutdownSubject, []byte("message")))
+		}
+
+		require.NoError(t, publisher.Close())
+		require.Eventually(t, func() bool { return processed.Load() > 0 }, time.Second, time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		require.NoError(t, consumer.Shutdown(ctx))
+		require.Equal(t, int64(5), processed.Load())
+
+		err = consumer.Shutdown(ctx)
+		require.IsType(t, &ConsumerAlreadyStoppedError{}, err)
+
+		err = consumer.Run()
+		require.IsType(t, &ConsumerAlreadyStoppedError{}, err)
+	})
+
+	t.Run("messages are abandoned after deadline", func(t *testing.T) {
+		started := make(chan struct{}, 1)
+
+		consumer, err := NewConsumer(
+			url,
+			shutdownSubject,
+			WithMessageChannelBufferSize(10),
+			WithHandler(func(ctx context.Context, _ *natsbroker.Msg) error {
+				started <- struct{}{}
+				<-ctx.Done()
+
+				return ctx.Err()
+			}),
+		)
+		require.NoError(t, err)
+		require.NoError(t, consumer.Run())
+
+		publisher, err := NewPublisher(url)
+		require.NoError(t, err)
+
+		for range 3 {
+			require.NoError(t, publisher.Publish(shutdownSubject, []byte("message")))
+		}
+
+		require.NoError(t, publisher.Close())
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		err = consumer.Shutdown(ctx)
+
+		var abandonedErr *AbandonedMessagesError
+		require.ErrorAs(t, err, &abandonedErr)
+		require.Equal(t, 3, abandonedErr.Abandoned)
+	})
+}
@@ -0,0 +1,47 @@
+package nats
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	natsbroker "github.com/nats-io/nats.go"
+)
+
+// Headers, which are added to message, published to dead-letter subject.
+const (
+	DeadLetterOriginalSubjectHeader = "Nats-Dlq-Original-Subject"
+	DeadLetterErrorHeader           = "Nats-Dlq-Error"
+	DeadLetterAttemptsHeader        = "Nats-Dlq-Attempts"
+	DeadLetterFailedAtHeader        = "Nats-Dlq-Failed-At"
+)
+
+// newDeadLetterMessage creates message for dead-letter subject with data and headers of original message
+// and details of processing failure.
+func newDeadLetterMessage(
+	subject string,
+	original *natsbroker.Msg,
+	err error,
+	attempts int,
+) *natsbroker.Msg {
+	message := natsbroker.NewMsg(subject)
+	message.Data = original.Data
+
+	for key, values := range original.Header {
+		for _, value := range values {
+			message.Header.Add(key, value)
+		}
+	}
+
+	message.Header.Set(DeadLetterOriginalSubjectHeader, original.Subject)
+	message.Header.Set(DeadLetterErrorHeader, headerValue(err.Error()))
+	message.Header.Set(DeadLetterAttemptsHeader, strconv.Itoa(attempts))
+	message.Header.Set(DeadLetterFailedAtHeader, time.Now().UTC().Format(time.RFC3339Nano))
+
+	return message
+}
+
+// headerValue replaces line breaks, which are not allowed in header values, with spaces.
+func headerValue(value string) string {
+	return strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(value)
+}
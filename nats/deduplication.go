@@ -0,0 +1,137 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	natsbroker "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	defaultDeduplicationTTL = 24 * time.Hour
+	memoryStoreCleanupEvery = time.Minute
+)
+
+// DeduplicationStore records IDs of messages, which are being processed or were processed.
+// Stores, based on cache and PostgreSQL, are provided by nats/deduplication package.
+type DeduplicationStore interface {
+	// Reserve records message ID for provided ttl. Returns false, if ID was already recorded.
+	Reserve(ctx context.Context, messageID string, ttl time.Duration) (bool, error)
+
+	// Release removes message ID, so message is processed again after redelivery.
+	Release(ctx context.Context, messageID string) error
+}
+
+// MessageID returns ID of message from Nats-Msg-Id header or from event envelope ID.
+// Returns empty string, if message has no ID.
+func MessageID(message *natsbroker.Msg) string {
+	if message.Header == nil {
+		return ""
+	}
+
+	if id := message.Header.Get(jetstream.MsgIDHeader); id != "" {
+		return id
+	}
+
+	return message.Header.Get(EventIDHeader)
+}
+
+// Deduplicate creates MessageHandler, which skips messages with IDs, already recorded in store.
+// ID is recorded before calling handler and is released, if handler failed or panicked, so message can be retried.
+// Messages without ID are always processed.
+func Deduplicate(store DeduplicationStore, ttl time.Duration, handler MessageHandler) MessageHandler {
+	if ttl <= 0 {
+		ttl = defaultDeduplicationTTL
+	}
+
+	return func(ctx context.Context, message *natsbroker.Msg) (err error) {
+		messageID := MessageID(message)
+		if messageID == "" {
+			return handler(ctx, message)
+		}
+
+		reserved, err := store.Reserve(ctx, messageID, ttl)
+		if err != nil {
+			return err
+		}
+
+		if !reserved {
+			return nil
+		}
+
+		succeeded := false
+
+		// ID is released on error and on panic, so message is not treated as processed during retry:
+		defer func() {
+			if succeeded {
+				return
+			}
+
+			if releaseErr := store.Release(context.WithoutCancel(ctx), messageID); releaseErr != nil && err != nil {
+				err = fmt.Errorf("%w; failed to release message ID: %w", err, releaseErr)
+			}
+		}()
+
+		if err = handler(ctx, message); err != nil {
+			return err
+		}
+
+		succeeded = true
+
+		return nil
+	}
+}
+
+// MemoryDeduplicationStore is an in-memory DeduplicationStore, which forgets IDs after their ttl.
+// It deduplicates messages only within single process.
+type MemoryDeduplicationStore struct {
+	expirations map[string]time.Time
+	cleanedAt   time.Time
+	mu          sync.Mutex
+}
+
+// NewMemoryDeduplicationStore creates empty *MemoryDeduplicationStore.
+func NewMemoryDeduplicationStore() *MemoryDeduplicationStore {
+	return &MemoryDeduplicationStore{
+		expirations: make(map[string]time.Time),
+		cleanedAt:   time.Now(),
+	}
+}
+
+// Reserve records message ID for provided ttl. Returns false, if ID was already recorded and is not expired.
+func (s *MemoryDeduplicationStore) Reserve(_ context.Context, messageID string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.cleanedAt) >= memoryStoreCleanupEvery {
+		for id, expiration := range s.expirations {
+			if !expiration.After(now) {
+				delete(s.expirations, id)
+			}
+		}
+
+		s.cleanedAt = now
+	}
+
+	if expiration, ok := s.expirations[messageID]; ok && expiration.After(now) {
+		return false, nil
+	}
+
+	s.expirations[messageID] = now.Add(ttl)
+
+	return true, nil
+}
+
+// Release removes message ID.
+func (s *MemoryDeduplicationStore) Release(_ context.Context, messageID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.expirations, messageID)
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package deduplication
+
+import (
+	"context"
+	"time"
+
+	"github.com/DKhorkov/libs/cache"
+	"github.com/google/uuid"
+)
+
+// CacheStore is a nats.DeduplicationStore, based on cache.Provider, which deduplicates messages
+// between all consumers, sharing the same cache.
+type CacheStore struct {
+	provider  cache.Provider
+	keyPrefix string
+}
+
+// NewCacheStore creates *CacheStore, which stores IDs under keys with provided prefix.
+func NewCacheStore(provider cache.Provider, keyPrefix string) *CacheStore {
+	return &CacheStore{
+		provider:  provider,
+		keyPrefix: keyPrefix,
+	}
+}
+
+// Reserve records message ID for provided ttl. Returns false, if ID was already recorded.
+// Key is set with unique token only if it does not exist, so reservation succeeds, if key contains this token.
+func (s *CacheStore) Reserve(ctx context.Context, messageID string, ttl time.Duration) (bool, error) {
+	key := s.keyPrefix + messageID
+	token := uuid.NewString()
+
+	if err := s.provider.SetNX(ctx, key, token, ttl); err != nil {
+		return false, err
+	}
+
+	value, err := s.provider.Get(ctx, key)
+	if err != nil {
+		return false, err
+	}
+
+	return value == token, nil
+}
+
+// Release removes message ID.
+func (s *CacheStore) Release(ctx context.Context, messageID string) error {
+	return s.provider.Del(ctx, s.keyPrefix+messageID)
+}
@@ -0,0 +1,49 @@
+package deduplication
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockcache "github.com/DKhorkov/libs/cache/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCacheStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	provider := mockcache.NewMockProvider(ctrl)
+	store := NewCacheStore(provider, "processed:")
+
+	var token any
+
+	provider.EXPECT().
+		SetNX(ctx, "processed:id", gomock.Any(), time.Hour).
+		DoAndReturn(func(_ context.Context, _ string, value any, _ time.Duration) error {
+			token = value
+
+			return nil
+		})
+	provider.EXPECT().
+		Get(ctx, "processed:id").
+		DoAndReturn(func(context.Context, string) (string, error) {
+			return token.(string), nil
+		})
+
+	reserved, err := store.Reserve(ctx, "id", time.Hour)
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	provider.EXPECT().SetNX(ctx, "processed:id", gomock.Any(), time.Hour).Return(nil)
+	provider.EXPECT().Get(ctx, "processed:id").Return("other-token", nil)
+
+	reserved, err = store.Reserve(ctx, "id", time.Hour)
+	require.NoError(t, err)
+	require.False(t, reserved)
+
+	provider.EXPECT().Del(ctx, "processed:id").Return(nil)
+	require.NoError(t, store.Release(ctx, "id"))
+}
@@ -0,0 +1,4 @@
+// Package deduplication provides nats.DeduplicationStore implementations and handlers, which deduplicate NATS
+// messages via cache and PostgreSQL. It is separated from nats package, so consumers without deduplication
+// do not depend on cache and database drivers.
+package deduplication
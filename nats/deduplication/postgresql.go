@@ -0,0 +1,79 @@
+package deduplication
+
+import (
+	"context"
+
+	"github.com/DKhorkov/libs/db/postgresql"
+	customnats "github.com/DKhorkov/libs/nats"
+	natsbroker "github.com/nats-io/nats.go"
+)
+
+// TransactionalMessageHandler processes message within transaction, in which message ID was recorded.
+type TransactionalMessageHandler func(
+	ctx context.Context,
+	transaction postgresql.Transaction,
+	message *natsbroker.Msg,
+) error
+
+// InTransaction creates nats.MessageHandler, which records message ID in provided PostgreSQL table
+// and calls handler within the same transaction. Transaction is committed only if handler succeeded,
+// so message ID is recorded together with handler changes. Messages with already recorded IDs are skipped,
+// messages without ID are processed within transaction without recording. Table should have unique
+// message_id column and processed_at column, for example:
+//
+//	CREATE TABLE processed_messages (
+//		message_id   TEXT PRIMARY KEY,
+//		processed_at TIMESTAMPTZ NOT NULL
+//	);
+//
+// Table name is used in query as is and should not be taken from untrusted input.
+func InTransaction(
+	connector postgresql.Connector,
+	table string,
+	handler TransactionalMessageHandler,
+	opts ...postgresql.TransactionOption,
+) customnats.MessageHandler {
+	query := "INSERT INTO " + table + " (message_id, processed_at) VALUES ($1, NOW()) ON CONFLICT DO NOTHING"
+
+	return func(ctx context.Context, message *natsbroker.Msg) error {
+		transaction, err := connector.Transaction(ctx, opts...)
+		if err != nil {
+			return err
+		}
+
+		finished := false
+
+		// Transaction is rolled back on error and on panic of handler:
+		defer func() {
+			if !finished {
+				_ = transaction.Rollback()
+			}
+		}()
+
+		if messageID := customnats.MessageID(message); messageID != "" {
+			result, execErr := transaction.ExecContext(ctx, query, messageID)
+			if execErr != nil {
+				return execErr
+			}
+
+			inserted, rowsErr := result.RowsAffected()
+			if rowsErr != nil {
+				return rowsErr
+			}
+
+			if inserted == 0 {
+				finished = true
+
+				return transaction.Rollback()
+			}
+		}
+
+		if err = handler(ctx, transaction, message); err != nil {
+			return err
+		}
+
+		finished = true
+
+		return transaction.Commit()
+	}
+}
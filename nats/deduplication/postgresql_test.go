@@ -0,0 +1,122 @@
+package deduplication
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DKhorkov/libs/db/postgresql"
+	mockpostgresql "github.com/DKhorkov/libs/db/postgresql/mocks"
+	customnats "github.com/DKhorkov/libs/nats"
+	natsbroker "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func messageWithID(id string) *natsbroker.Msg {
+	message := natsbroker.NewMsg("test.deduplication")
+	message.Header.Set(jetstream.MsgIDHeader, id)
+
+	return message
+}
+
+// rowsAffectedResult is sql.Result with fixed number of affected rows.
+type rowsAffectedResult int64
+
+func (r rowsAffectedResult) LastInsertId() (int64, error) {
+	return 0, nil
+}
+
+func (r rowsAffectedResult) RowsAffected() (int64, error) {
+	return int64(r), nil
+}
+
+func TestInTransaction(t *testing.T) {
+	t.Parallel()
+
+	const query = "INSERT INTO processed_messages (message_id, processed_at) VALUES ($1, NOW()) ON CONFLICT DO NOTHING"
+
+	ctx := context.Background()
+
+	newHandler := func(t *testing.T, calls *int, handlerErr error) (customnats.MessageHandler, *mockpostgresql.MockTransaction) {
+		ctrl := gomock.NewController(t)
+		connector := mockpostgresql.NewMockConnector(ctrl)
+		transaction := mockpostgresql.NewMockTransaction(ctrl)
+		connector.EXPECT().Transaction(ctx).Return(transaction, nil)
+
+		handler := InTransaction(
+			connector,
+			"processed_messages",
+			func(_ context.Context, tx postgresql.Transaction, _ *natsbroker.Msg) error {
+				require.Equal(t, transaction, tx)
+				*calls++
+
+				return handlerErr
+			},
+		)
+
+		return handler, transaction
+	}
+
+	t.Run("new message is processed and committed", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+
+		handler, transaction := newHandler(t, &calls, nil)
+		transaction.EXPECT().ExecContext(ctx, query, "id").Return(rowsAffectedResult(1), nil)
+		transaction.EXPECT().Commit().Return(nil)
+
+		require.NoError(t, handler(ctx, messageWithID("id")))
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("duplicate is skipped", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+
+		handler, transaction := newHandler(t, &calls, nil)
+		transaction.EXPECT().ExecContext(ctx, query, "id").Return(rowsAffectedResult(0), nil)
+		transaction.EXPECT().Rollback().Return(nil)
+
+		require.NoError(t, handler(ctx, messageWithID("id")))
+		require.Zero(t, calls)
+	})
+
+	t.Run("panicked handler rolls back transaction", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		connector := mockpostgresql.NewMockConnector(ctrl)
+		transaction := mockpostgresql.NewMockTransaction(ctrl)
+		connector.EXPECT().Transaction(ctx).Return(transaction, nil)
+		transaction.EXPECT().ExecContext(ctx, query, "id").Return(rowsAffectedResult(1), nil)
+		transaction.EXPECT().Rollback().Return(nil)
+
+		handler := InTransaction(
+			connector,
+			"processed_messages",
+			func(context.Context, postgresql.Transaction, *natsbroker.Msg) error {
+				panic("handler panic")
+			},
+		)
+
+		require.Panics(t, func() { _ = handler(ctx, messageWithID("id")) })
+	})
+
+	t.Run("failed handler rolls back transaction", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+
+		handlerErr := errors.New("handler error")
+		handler, transaction := newHandler(t, &calls, handlerErr)
+		transaction.EXPECT().ExecContext(ctx, query, "id").Return(rowsAffectedResult(1), nil)
+		transaction.EXPECT().Rollback().Return(nil)
+
+		require.ErrorIs(t, handler(ctx, messageWithID("id")), handlerErr)
+		require.Equal(t, 1, calls)
+	})
+}
@@ -0,0 +1,122 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	natsbroker "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/require"
+)
+
+func messageWithID(id string) *natsbroker.Msg {
+	message := natsbroker.NewMsg("test.deduplication")
+	message.Header.Set(jetstream.MsgIDHeader, id)
+
+	return message
+}
+
+func TestMessageID(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "message-id", MessageID(messageWithID("message-id")))
+
+	message := natsbroker.NewMsg("test")
+	message.Header.Set(EventIDHeader, "event-id")
+	require.Equal(t, "event-id", MessageID(message))
+
+	require.Empty(t, MessageID(&natsbroker.Msg{Subject: "test"}))
+}
+
+func TestMemoryDeduplicationStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewMemoryDeduplicationStore()
+
+	reserved, err := store.Reserve(ctx, "id", time.Hour)
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	reserved, err = store.Reserve(ctx, "id", time.Hour)
+	require.NoError(t, err)
+	require.False(t, reserved)
+
+	require.NoError(t, store.Release(ctx, "id"))
+
+	reserved, err = store.Reserve(ctx, "id", time.Nanosecond)
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	time.Sleep(time.Millisecond)
+
+	reserved, err = store.Reserve(ctx, "id", time.Hour)
+	require.NoError(t, err)
+	require.True(t, reserved)
+}
+
+func TestDeduplicate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("duplicates are skipped", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+
+		handler := Deduplicate(NewMemoryDeduplicationStore(), 0, func(context.Context, *natsbroker.Msg) error {
+			calls++
+
+			return nil
+		})
+
+		require.NoError(t, handler(ctx, messageWithID("id")))
+		require.NoError(t, handler(ctx, messageWithID("id")))
+		require.NoError(t, handler(ctx, messageWithID("other")))
+		require.NoError(t, handler(ctx, &natsbroker.Msg{}))
+		require.NoError(t, handler(ctx, &natsbroker.Msg{}))
+		require.Equal(t, 4, calls)
+	})
+
+	t.Run("failed message is released", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+
+		handlerErr := errors.New("handler error")
+		handler := Deduplicate(NewMemoryDeduplicationStore(), time.Hour, func(context.Context, *natsbroker.Msg) error {
+			calls++
+			if calls == 1 {
+				return handlerErr
+			}
+
+			return nil
+		})
+
+		require.ErrorIs(t, handler(ctx, messageWithID("id")), handlerErr)
+		require.NoError(t, handler(ctx, messageWithID("id")))
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("panicked message is released", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int
+
+		handler := Deduplicate(NewMemoryDeduplicationStore(), time.Hour, func(context.Context, *natsbroker.Msg) error {
+			calls++
+			if calls == 1 {
+				panic("handler panic")
+			}
+
+			return nil
+		})
+
+		require.Panics(t, func() { _ = handler(ctx, messageWithID("id")) })
+		require.NoError(t, handler(ctx, messageWithID("id")))
+		require.Equal(t, 2, calls)
+	})
+}
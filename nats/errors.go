@@ -48,3 +48,360 @@ func (e ConsumerAlreadyStoppedError) Error() string {
 func (e ConsumerAlreadyStoppedError) Unwrap() error {
 	return e.BaseErr
 }
+
+// TerminateError is an error, which should be returned by JetStream message handler for poisoned messages,
+// which can never be processed successfully. Such messages are terminated and are not redelivered.
+type TerminateError struct {
+	Message string
+	BaseErr error
+}
+
+func (e TerminateError) Error() string {
+	template := "message processing is terminated"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e TerminateError) Unwrap() error {
+	return e.BaseErr
+}
+
+// HandlerPanicError is an error, which represents, that message handler panicked during message processing.
+type HandlerPanicError struct {
+	Message string
+	BaseErr error
+}
+
+func (e HandlerPanicError) Error() string {
+	template := "message handler panicked"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e HandlerPanicError) Unwrap() error {
+	return e.BaseErr
+}
+
+// MessageProcessingError is an error, which represents, that all attempts of message processing failed.
+type MessageProcessingError struct {
+	Message string
+	BaseErr error
+}
+
+func (e MessageProcessingError) Error() string {
+	template := "message processing failed"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e MessageProcessingError) Unwrap() error {
+	return e.BaseErr
+}
+
+// RPCError is an error, which is returned by RPC handler and is transferred to requester in reply headers.
+// Code allows requester to distinguish errors without parsing message.
+type RPCError struct {
+	Code    string
+	Message string
+	BaseErr error
+}
+
+func (e RPCError) Error() string {
+	template := "rpc request failed"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.Code != "" {
+		template = fmt.Sprintf("%s (code: %s)", template, e.Code)
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e RPCError) Unwrap() error {
+	return e.BaseErr
+}
+
+// InvalidSubjectError is an error, which represents, that subject or subject pattern is not valid.
+type InvalidSubjectError struct {
+	Message string
+	BaseErr error
+}
+
+func (e InvalidSubjectError) Error() string {
+	template := "invalid subject"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e InvalidSubjectError) Unwrap() error {
+	return e.BaseErr
+}
+
+// RouteNotFoundError is an error, which represents, that there is no handler for message subject.
+type RouteNotFoundError struct {
+	Message string
+	BaseErr error
+}
+
+func (e RouteNotFoundError) Error() string {
+	template := "route not found"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e RouteNotFoundError) Unwrap() error {
+	return e.BaseErr
+}
+
+// AbandonedMessagesError is an error, which represents, that consumer shutdown deadline exceeded and some
+// received messages were not processed.
+type AbandonedMessagesError struct {
+	Message   string
+	BaseErr   error
+	Abandoned int
+}
+
+func (e AbandonedMessagesError) Error() string {
+	template := "messages were abandoned during consumer shutdown"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e AbandonedMessagesError) Unwrap() error {
+	return e.BaseErr
+}
+
+// NotFoundError is an error, which represents, that key or object does not exist.
+type NotFoundError struct {
+	Message string
+	BaseErr error
+}
+
+func (e NotFoundError) Error() string {
+	template := "not found"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e NotFoundError) Unwrap() error {
+	return e.BaseErr
+}
+
+// KeyExistsError is an error, which represents, that key can not be created, because it already exists.
+type KeyExistsError struct {
+	Message string
+	BaseErr error
+}
+
+func (e KeyExistsError) Error() string {
+	template := "key already exists"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e KeyExistsError) Unwrap() error {
+	return e.BaseErr
+}
+
+// RevisionMismatchError is an error, which represents, that key can not be updated, because it was changed
+// after expected revision.
+type RevisionMismatchError struct {
+	Message string
+	BaseErr error
+}
+
+func (e RevisionMismatchError) Error() string {
+	template := "revision does not match"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e RevisionMismatchError) Unwrap() error {
+	return e.BaseErr
+}
+
+// UnknownEventError is an error, which represents, that event type, version or payload type is not registered.
+type UnknownEventError struct {
+	Message string
+	BaseErr error
+}
+
+func (e UnknownEventError) Error() string {
+	template := "unknown event"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e UnknownEventError) Unwrap() error {
+	return e.BaseErr
+}
+
+// InvalidEventError is an error, which represents, that event or its registration is not valid.
+type InvalidEventError struct {
+	Message string
+	BaseErr error
+}
+
+func (e InvalidEventError) Error() string {
+	template := "invalid event"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e InvalidEventError) Unwrap() error {
+	return e.BaseErr
+}
+
+// PublisherClosedError is an error, which represents, that message was published after publisher was closed.
+type PublisherClosedError struct {
+	Message string
+	BaseErr error
+}
+
+func (e PublisherClosedError) Error() string {
+	template := "publisher is closed"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e PublisherClosedError) Unwrap() error {
+	return e.BaseErr
+}
+
+// BufferFullError is an error, which represents, that publisher buffer is full and message was not accepted.
+type BufferFullError struct {
+	Message string
+	BaseErr error
+}
+
+func (e BufferFullError) Error() string {
+	template := "publisher buffer is full"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e BufferFullError) Unwrap() error {
+	return e.BaseErr
+}
+
+// UnflushedMessagesError is an error, which represents, that publisher flush or shutdown deadline exceeded
+// and some messages were not published.
+type UnflushedMessagesError struct {
+	Message   string
+	BaseErr   error
+	Unflushed int
+}
+
+func (e UnflushedMessagesError) Error() string {
+	template := "messages were not flushed"
+	if e.Message != "" {
+		template = e.Message
+	}
+
+	if e.BaseErr != nil {
+		return fmt.Sprintf(template+". Base error: %v", e.BaseErr)
+	}
+
+	return template
+}
+
+func (e UnflushedMessagesError) Unwrap() error {
+	return e.BaseErr
+}
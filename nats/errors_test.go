@@ -1,6 +1,7 @@
 package nats_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -106,3 +107,199 @@ func TestConsumerAlreadyStoppedError(t *testing.T) {
 		require.Equal(t, baseErr, err.Unwrap())
 	})
 }
+
+func TestTerminateError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Default message without base error", func(t *testing.T) {
+		t.Parallel()
+
+		err := customnats.TerminateError{}
+		require.Equal(t, "message processing is terminated", err.Error())
+		require.NoError(t, err.Unwrap())
+	})
+
+	t.Run("Default message with base error", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errors.New("invalid payload")
+		err := customnats.TerminateError{BaseErr: baseErr}
+		expected := fmt.Sprintf("message processing is terminated. Base error: %v", baseErr)
+		require.Equal(t, expected, err.Error())
+		require.ErrorIs(t, err, baseErr)
+	})
+}
+
+func TestHandlerPanicError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Default message without base error", func(t *testing.T) {
+		t.Parallel()
+
+		err := customnats.HandlerPanicError{}
+		require.Equal(t, "message handler panicked", err.Error())
+		require.NoError(t, err.Unwrap())
+	})
+
+	t.Run("Default message with base error", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errors.New("boom")
+		err := customnats.HandlerPanicError{BaseErr: baseErr}
+		expected := fmt.Sprintf("message handler panicked. Base error: %v", baseErr)
+		require.Equal(t, expected, err.Error())
+		require.ErrorIs(t, err, baseErr)
+	})
+}
+
+func TestMessageProcessingError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Default message without base error", func(t *testing.T) {
+		t.Parallel()
+
+		err := customnats.MessageProcessingError{}
+		require.Equal(t, "message processing failed", err.Error())
+		require.NoError(t, err.Unwrap())
+	})
+
+	t.Run("Custom message with base error", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errors.New("handler error")
+		err := customnats.MessageProcessingError{
+			Message: "message processing failed after 3 attempts",
+			BaseErr: baseErr,
+		}
+		expected := fmt.Sprintf("message processing failed after 3 attempts. Base error: %v", baseErr)
+		require.Equal(t, expected, err.Error())
+		require.ErrorIs(t, err, baseErr)
+	})
+}
+
+func TestRPCError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Default message without code", func(t *testing.T) {
+		t.Parallel()
+
+		err := customnats.RPCError{}
+		require.Equal(t, "rpc request failed", err.Error())
+		require.NoError(t, err.Unwrap())
+	})
+
+	t.Run("Custom message with code and base error", func(t *testing.T) {
+		t.Parallel()
+
+		baseErr := errors.New("base error")
+		err := customnats.RPCError{
+			Code:    "not_found",
+			Message: "user not found",
+			BaseErr: baseErr,
+		}
+		expected := fmt.Sprintf("user not found (code: not_found). Base error: %v", baseErr)
+		require.Equal(t, expected, err.Error())
+		require.ErrorIs(t, err, baseErr)
+	})
+}
+
+func TestAbandonedMessagesError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Default message without base error", func(t *testing.T) {
+		t.Parallel()
+
+		err := customnats.AbandonedMessagesError{Abandoned: 1}
+		require.Equal(t, "messages were abandoned during consumer shutdown", err.Error())
+		require.NoError(t, err.Unwrap())
+	})
+
+	t.Run("Custom message with base error", func(t *testing.T) {
+		t.Parallel()
+
+		err := customnats.AbandonedMessagesError{
+			Message:   "2 messages were abandoned",
+			BaseErr:   context.DeadlineExceeded,
+			Abandoned: 2,
+		}
+		expected := fmt.Sprintf("2 messages were abandoned. Base error: %v", context.DeadlineExceeded)
+		require.Equal(t, expected, err.Error())
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestStoreErrors(t *testing.T) {
+	t.Parallel()
+
+	baseErr := errors.New("base error")
+
+	testCases := []struct {
+		name            string
+		err             error
+		expectedDefault string
+	}{
+		{
+			name:            "not found",
+			err:             customnats.NotFoundError{BaseErr: baseErr},
+			expectedDefault: "not found",
+		},
+		{
+			name:            "key exists",
+			err:             customnats.KeyExistsError{BaseErr: baseErr},
+			expectedDefault: "key already exists",
+		},
+		{
+			name:            "revision mismatch",
+			err:             customnats.RevisionMismatchError{BaseErr: baseErr},
+			expectedDefault: "revision does not match",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			expected := fmt.Sprintf("%s. Base error: %v", tc.expectedDefault, baseErr)
+			require.Equal(t, expected, tc.err.Error())
+			require.ErrorIs(t, tc.err, baseErr)
+		})
+	}
+}
+
+func TestPublisherErrors(t *testing.T) {
+	t.Parallel()
+
+	baseErr := errors.New("base error")
+
+	testCases := []struct {
+		name            string
+		err             error
+		expectedDefault string
+	}{
+		{
+			name:            "publisher closed",
+			err:             customnats.PublisherClosedError{BaseErr: baseErr},
+			expectedDefault: "publisher is closed",
+		},
+		{
+			name:            "buffer full",
+			err:             customnats.BufferFullError{BaseErr: baseErr},
+			expectedDefault: "publisher buffer is full",
+		},
+		{
+			name:            "unflushed messages",
+			err:             customnats.UnflushedMessagesError{BaseErr: baseErr, Unflushed: 1},
+			expectedDefault: "messages were not flushed",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			expected := fmt.Sprintf("%s. Base error: %v", tc.expectedDefault, baseErr)
+			require.Equal(t, expected, tc.err.Error())
+			require.ErrorIs(t, tc.err, baseErr)
+		})
+	}
+}
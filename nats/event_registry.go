@@ -0,0 +1,190 @@
+package nats
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// eventKey identifies version of event type.
+type eventKey struct {
+	eventType string
+	version   int
+}
+
+// upcaster converts payload of event version to payload of the next version.
+type upcaster func(payload any) (any, error)
+
+// EventRegistry maps event types and versions to Go types of their payloads and stores upcasters,
+// which convert payloads of old versions to the newer ones. Registry is safe for concurrent usage.
+type EventRegistry struct {
+	codec     Codec
+	codecs    map[string]Codec
+	source    string
+	types     map[eventKey]reflect.Type
+	keys      map[reflect.Type]eventKey
+	upcasters map[eventKey]upcaster
+	mu        sync.RWMutex
+}
+
+// NewEventRegistry creates empty *EventRegistry with provided options.
+func NewEventRegistry(opts ...EventRegistryOption) (*EventRegistry, error) {
+	options := newEventRegistryOptions()
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, err
+		}
+	}
+
+	codecs := map[string]Codec{
+		ContentTypeJSON:     JSONCodec{},
+		ContentTypeProtobuf: ProtobufCodec{},
+	}
+	codecs[options.codec.ContentType()] = options.codec
+
+	return &EventRegistry{
+		codec:     options.codec,
+		codecs:    codecs,
+		source:    options.source,
+		types:     make(map[eventKey]reflect.Type),
+		keys:      make(map[reflect.Type]eventKey),
+		upcasters: make(map[eventKey]upcaster),
+	}, nil
+}
+
+// RegisterEvent registers T as a payload type of provided version of event type. Published events with
+// payload of type T get provided type and version. Version should be positive. Payload type can be registered
+// only once, so each event version should have its own payload type.
+func RegisterEvent[T any](registry *EventRegistry, eventType string, version int) error {
+	if eventType == "" || version < 1 {
+		return &InvalidEventError{
+			Message: fmt.Sprintf("event type should not be empty and version should be positive, got %q v%d", eventType, version),
+		}
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	key := eventKey{eventType: eventType, version: version}
+	if registered, ok := registry.types[key]; ok {
+		return &InvalidEventError{
+			Message: fmt.Sprintf("event %s v%d is already registered with payload type %s", eventType, version, registered),
+		}
+	}
+
+	payloadType := reflect.TypeFor[T]()
+	if registered, ok := registry.keys[payloadType]; ok {
+		return &InvalidEventError{
+			Message: fmt.Sprintf(
+				"payload type %s is already registered for event %s v%d",
+				payloadType,
+				registered.eventType,
+				registered.version,
+			),
+		}
+	}
+
+	registry.types[key] = payloadType
+	registry.keys[payloadType] = key
+
+	return nil
+}
+
+// RegisterUpcaster registers function, which converts payload of provided version of event type to payload
+// of the next version. Both versions should be registered with payload types From and To. Consumed events
+// are upcasted version by version until the latest registered version.
+func RegisterUpcaster[From, To any](
+	registry *EventRegistry,
+	eventType string,
+	fromVersion int,
+	convert func(payload From) (To, error),
+) error {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	from := eventKey{eventType: eventType, version: fromVersion}
+	to := eventKey{eventType: eventType, version: fromVersion + 1}
+
+	if registry.types[from] != reflect.TypeFor[From]() || registry.types[to] != reflect.TypeFor[To]() {
+		return &InvalidEventError{
+			Message: fmt.Sprintf(
+				"event %s v%d and v%d should be registered with payload types %s and %s",
+				eventType,
+				from.version,
+				to.version,
+				reflect.TypeFor[From](),
+				reflect.TypeFor[To](),
+			),
+		}
+	}
+
+	registry.upcasters[from] = func(payload any) (any, error) {
+		typed, ok := payload.(From)
+		if !ok {
+			return nil, &InvalidEventError{Message: fmt.Sprintf("unexpected payload type %T", payload)}
+		}
+
+		return convert(typed)
+	}
+
+	return nil
+}
+
+// keyOf returns event type and version, which were registered for payload type.
+func (r *EventRegistry) keyOf(payloadType reflect.Type) (eventKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, ok := r.keys[payloadType]
+	if !ok {
+		return eventKey{}, &UnknownEventError{Message: fmt.Sprintf("payload type %s is not registered", payloadType)}
+	}
+
+	return key, nil
+}
+
+// codecFor returns codec for provided content type. Default codec of registry is used for empty content type.
+func (r *EventRegistry) codecFor(contentType string) (Codec, error) {
+	if contentType == "" {
+		return r.codec, nil
+	}
+
+	codec, ok := r.codecs[contentType]
+	if !ok {
+		return nil, &UnknownEventError{Message: "unsupported content type " + contentType}
+	}
+
+	return codec, nil
+}
+
+// decode decodes payload of provided event version and upcasts it to the latest version, which has upcaster.
+// Returns payload and its version.
+func (r *EventRegistry) decode(key eventKey, codec Codec, data []byte) (any, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	payloadType, ok := r.types[key]
+	if !ok {
+		return nil, 0, &UnknownEventError{
+			Message: fmt.Sprintf("event %s v%d is not registered", key.eventType, key.version),
+		}
+	}
+
+	payload, err := decodeType(codec, data, payloadType)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for {
+		upcast, ok := r.upcasters[key]
+		if !ok {
+			return payload, key.version, nil
+		}
+
+		if payload, err = upcast(payload); err != nil {
+			return nil, 0, err
+		}
+
+		key.version++
+	}
+}
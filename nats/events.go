@@ -0,0 +1,151 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/DKhorkov/libs/contextlib"
+	"github.com/DKhorkov/libs/requestid"
+	"github.com/google/uuid"
+	natsbroker "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// Headers, which contain envelope fields of event. Payload is stored in message data.
+const (
+	EventIDHeader         = "Nats-Event-Id"
+	EventTypeHeader       = "Nats-Event-Type"
+	EventVersionHeader    = "Nats-Event-Version"
+	EventOccurredAtHeader = "Nats-Event-Occurred-At"
+	EventSourceHeader     = "Nats-Event-Source"
+)
+
+// Envelope is a typed event with metadata, which allows consumers to recognize format of payload.
+type Envelope[T any] struct {
+	ID         string
+	Type       string
+	Version    int
+	OccurredAt time.Time
+	Source     string
+	RequestID  string
+	Payload    T
+}
+
+// Publish wraps payload to envelope and publishes it to provided subject. Type and version of event are taken
+// from registry by type of payload. Request ID and trace context of ctx are propagated in headers.
+// Event ID is also set to Nats-Msg-Id header, so JetStream streams deduplicate repeated publications.
+func Publish[T any](
+	ctx context.Context,
+	publisher ContextPublisher,
+	registry *EventRegistry,
+	subject string,
+	payload T,
+) (*Envelope[T], error) {
+	key, err := registry.keyOf(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := registry.codec.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope := &Envelope[T]{
+		ID:         uuid.NewString(),
+		Type:       key.eventType,
+		Version:    key.version,
+		OccurredAt: time.Now().UTC(),
+		Source:     registry.source,
+		Payload:    payload,
+	}
+
+	if requestID, err := contextlib.ValueFromContext[string](ctx, requestid.Key); err == nil {
+		envelope.RequestID = requestID
+	}
+
+	headers := natsbroker.Header{}
+	headers.Set(EventIDHeader, envelope.ID)
+	headers.Set(EventTypeHeader, envelope.Type)
+	headers.Set(EventVersionHeader, strconv.Itoa(envelope.Version))
+	headers.Set(EventOccurredAtHeader, envelope.OccurredAt.Format(time.RFC3339Nano))
+	headers.Set(ContentTypeHeader, registry.codec.ContentType())
+	headers.Set(jetstream.MsgIDHeader, envelope.ID)
+
+	if envelope.Source != "" {
+		headers.Set(EventSourceHeader, envelope.Source)
+	}
+
+	if err = publisher.PublishContext(ctx, subject, data, headers); err != nil {
+		return nil, err
+	}
+
+	return envelope, nil
+}
+
+// Handle creates MessageHandler, which decodes event envelope, upcasts payload of old versions via registry
+// and passes envelope to provided handler. Events, which can not be decoded to T, are returned as
+// *UnknownEventError or *InvalidEventError.
+func Handle[T any](
+	registry *EventRegistry,
+	handler func(ctx context.Context, envelope *Envelope[T]) error,
+) MessageHandler {
+	return func(ctx context.Context, message *natsbroker.Msg) error {
+		envelope, err := DecodeEnvelope[T](registry, message)
+		if err != nil {
+			return err
+		}
+
+		return handler(ctx, envelope)
+	}
+}
+
+// DecodeEnvelope decodes event envelope from message and upcasts its payload to the latest version.
+func DecodeEnvelope[T any](registry *EventRegistry, message *natsbroker.Msg) (*Envelope[T], error) {
+	eventType := message.Header.Get(EventTypeHeader)
+
+	version, err := strconv.Atoi(message.Header.Get(EventVersionHeader))
+	if err != nil || eventType == "" {
+		return nil, &InvalidEventError{
+			Message: "message from subject " + message.Subject + " has no valid event type and version",
+			BaseErr: err,
+		}
+	}
+
+	codec, err := registry.codecFor(message.Header.Get(ContentTypeHeader))
+	if err != nil {
+		return nil, err
+	}
+
+	payload, version, err := registry.decode(eventKey{eventType: eventType, version: version}, codec, message.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	typed, ok := payload.(T)
+	if !ok {
+		return nil, &UnknownEventError{
+			Message: fmt.Sprintf("event %s v%d has payload type %T instead of %s", eventType, version, payload, reflect.TypeFor[T]()),
+		}
+	}
+
+	envelope := &Envelope[T]{
+		ID:        message.Header.Get(EventIDHeader),
+		Type:      eventType,
+		Version:   version,
+		Source:    message.Header.Get(EventSourceHeader),
+		RequestID: message.Header.Get(requestid.Key),
+		Payload:   typed,
+	}
+
+	if occurredAt := message.Header.Get(EventOccurredAtHeader); occurredAt != "" {
+		if envelope.OccurredAt, err = time.Parse(time.RFC3339Nano, occurredAt); err != nil {
+			return nil, &InvalidEventError{Message: "invalid occurrence time of event " + envelope.ID, BaseErr: err}
+		}
+	}
+
+	return envelope, nil
+}
@@ -0,0 +1,36 @@
+package nats
+
+// newEventRegistryOptions creates *eventRegistryOptions with default values.
+func newEventRegistryOptions() *eventRegistryOptions {
+	return &eventRegistryOptions{
+		codec: JSONCodec{},
+	}
+}
+
+// eventRegistryOptions represents options for EventRegistry configuration.
+type eventRegistryOptions struct {
+	codec  Codec
+	source string
+}
+
+// EventRegistryOption represents golang functional option pattern func for EventRegistry configuration.
+type EventRegistryOption func(options *eventRegistryOptions) error
+
+// WithEventCodec sets codec for payloads of published events. JSONCodec is used by default.
+// Consumed events are decoded with codec, which content type is set in message headers.
+func WithEventCodec(codec Codec) EventRegistryOption {
+	return func(options *eventRegistryOptions) error {
+		options.codec = codec
+
+		return nil
+	}
+}
+
+// WithEventSource sets source, which is set to envelopes of published events, for example, name of service.
+func WithEventSource(source string) EventRegistryOption {
+	return func(options *eventRegistryOptions) error {
+		options.source = source
+
+		return nil
+	}
+}
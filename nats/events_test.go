@@ -0,0 +1,192 @@
+package nats
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/DKhorkov/libs/contextlib"
+	"github.com/DKhorkov/libs/requestid"
+	natsbroker "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type orderCreatedV1 struct {
+	ID    int    `json:"id"`
+	Price string `json:"price"`
+}
+
+type orderCreatedV2 struct {
+	ID    int     `json:"id"`
+	Price float64 `json:"price"`
+}
+
+type orderCreatedV3 struct {
+	ID       int     `json:"id"`
+	Price    float64 `json:"price"`
+	Currency string  `json:"currency"`
+}
+
+// recordingPublisher stores published messages instead of sending them to NATS.
+type recordingPublisher struct {
+	messages []*natsbroker.Msg
+}
+
+func (p *recordingPublisher) Publish(subject string, content []byte) error {
+	return p.PublishContext(context.Background(), subject, content, nil)
+}
+
+func (p *recordingPublisher) PublishContext(
+	ctx context.Context,
+	subject string,
+	content []byte,
+	headers natsbroker.Header,
+) error {
+	p.messages = append(p.messages, newMessage(ctx, subject, content, headers))
+
+	return nil
+}
+
+func (p *recordingPublisher) Close() error {
+	return nil
+}
+
+// newOrdersRegistry creates registry with three versions of order created event.
+func newOrdersRegistry(t *testing.T, opts ...EventRegistryOption) *EventRegistry {
+	t.Helper()
+
+	registry, err := NewEventRegistry(append([]EventRegistryOption{WithEventSource("orders")}, opts...)...)
+	require.NoError(t, err)
+
+	require.NoError(t, RegisterEvent[orderCreatedV1](registry, "order.created", 1))
+	require.NoError(t, RegisterEvent[orderCreatedV2](registry, "order.created", 2))
+	require.NoError(t, RegisterEvent[orderCreatedV3](registry, "order.created", 3))
+
+	require.NoError(
+		t,
+		RegisterUpcaster(registry, "order.created", 1, func(payload orderCreatedV1) (orderCreatedV2, error) {
+			price, err := strconv.ParseFloat(payload.Price, 64)
+
+			return orderCreatedV2{ID: payload.ID, Price: price}, err
+		}),
+	)
+	require.NoError(
+		t,
+		RegisterUpcaster(registry, "order.created", 2, func(payload orderCreatedV2) (orderCreatedV3, error) {
+			return orderCreatedV3{ID: payload.ID, Price: payload.Price, Currency: "USD"}, nil
+		}),
+	)
+
+	return registry
+}
+
+func TestPublishAndHandle(t *testing.T) {
+	t.Parallel()
+
+	registry := newOrdersRegistry(t)
+	publisher := &recordingPublisher{}
+	ctx := contextlib.WithValue(context.Background(), requestid.Key, "request-id")
+
+	published, err := Publish(ctx, publisher, registry, "orders", orderCreatedV3{ID: 1, Price: 9.5, Currency: "EUR"})
+	require.NoError(t, err)
+	require.Equal(t, "order.created", published.Type)
+	require.Equal(t, 3, published.Version)
+	require.Equal(t, "request-id", published.RequestID)
+	require.NotEmpty(t, published.ID)
+
+	require.Len(t, publisher.messages, 1)
+	message := publisher.messages[0]
+	require.Equal(t, published.ID, message.Header.Get("Nats-Msg-Id"))
+	require.Equal(t, ContentTypeJSON, message.Header.Get(ContentTypeHeader))
+
+	var handled *Envelope[orderCreatedV3]
+
+	handler := Handle(registry, func(_ context.Context, envelope *Envelope[orderCreatedV3]) error {
+		handled = envelope
+
+		return nil
+	})
+
+	require.NoError(t, handler(context.Background(), message))
+	require.Equal(t, published.ID, handled.ID)
+	require.Equal(t, "orders", handled.Source)
+	require.Equal(t, "request-id", handled.RequestID)
+	require.True(t, published.OccurredAt.Equal(handled.OccurredAt))
+	require.Equal(t, published.Payload, handled.Payload)
+}
+
+func TestHandleUpcastsOldVersions(t *testing.T) {
+	t.Parallel()
+
+	registry := newOrdersRegistry(t)
+	publisher := &recordingPublisher{}
+
+	_, err := Publish(context.Background(), publisher, registry, "orders", orderCreatedV1{ID: 1, Price: "10.25"})
+	require.NoError(t, err)
+
+	envelope, err := DecodeEnvelope[orderCreatedV3](registry, publisher.messages[0])
+	require.NoError(t, err)
+	require.Equal(t, 3, envelope.Version)
+	require.Equal(t, orderCreatedV3{ID: 1, Price: 10.25, Currency: "USD"}, envelope.Payload)
+
+	// Handler of old version can not receive upcasted event:
+	_, err = DecodeEnvelope[orderCreatedV1](registry, publisher.messages[0])
+	require.IsType(t, &UnknownEventError{}, err)
+}
+
+func TestEventRegistryErrors(t *testing.T) {
+	t.Parallel()
+
+	registry := newOrdersRegistry(t)
+
+	err := RegisterEvent[orderCreatedV1](registry, "order.created", 1)
+	require.IsType(t, &InvalidEventError{}, err)
+
+	err = RegisterEvent[orderCreatedV1](registry, "", 1)
+	require.IsType(t, &InvalidEventError{}, err)
+
+	err = RegisterEvent[orderCreatedV1](registry, "order.updated", 1)
+	require.IsType(t, &InvalidEventError{}, err)
+
+	err = RegisterUpcaster(registry, "order.created", 3, func(payload orderCreatedV3) (orderCreatedV1, error) {
+		return orderCreatedV1{}, nil
+	})
+	require.IsType(t, &InvalidEventError{}, err)
+
+	_, err = Publish(context.Background(), &recordingPublisher{}, registry, "orders", "not registered")
+	require.IsType(t, &UnknownEventError{}, err)
+
+	message := natsbroker.NewMsg("orders")
+	_, err = DecodeEnvelope[orderCreatedV3](registry, message)
+	require.IsType(t, &InvalidEventError{}, err)
+
+	message.Header.Set(EventTypeHeader, "order.deleted")
+	message.Header.Set(EventVersionHeader, "1")
+	_, err = DecodeEnvelope[orderCreatedV3](registry, message)
+	require.IsType(t, &UnknownEventError{}, err)
+
+	message.Header.Set(EventTypeHeader, "order.created")
+	message.Header.Set(ContentTypeHeader, "application/xml")
+	_, err = DecodeEnvelope[orderCreatedV3](registry, message)
+	require.IsType(t, &UnknownEventError{}, err)
+}
+
+func TestProtobufEvents(t *testing.T) {
+	t.Parallel()
+
+	registry, err := NewEventRegistry(WithEventCodec(ProtobufCodec{}))
+	require.NoError(t, err)
+	require.NoError(t, RegisterEvent[*wrapperspb.StringValue](registry, "user.renamed", 1))
+
+	publisher := &recordingPublisher{}
+
+	_, err = Publish(context.Background(), publisher, registry, "users", wrapperspb.String("name"))
+	require.NoError(t, err)
+	require.Equal(t, ContentTypeProtobuf, publisher.messages[0].Header.Get(ContentTypeHeader))
+
+	envelope, err := DecodeEnvelope[*wrapperspb.StringValue](registry, publisher.messages[0])
+	require.NoError(t, err)
+	require.True(t, proto.Equal(wrapperspb.String("name"), envelope.Payload))
+}
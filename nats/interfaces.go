@@ -1,8 +1,14 @@
 package nats
 
+import (
+	"context"
+
+	natsbroker "github.com/nats-io/nats.go"
+)
+
 // Consumer asynchronously processes NATS messages in goroutines.
 //
-//go:generate mockgen -source=interfaces.go -destination=mocks/consumer.go -package=mocks -exclude_interfaces=Publisher
+//go:generate mockgen -source=interfaces.go -destination=mocks/consumer.go -package=mocks -exclude_interfaces=Publisher,ContextPublisher
 type Consumer interface {
 	Run() error
 	Stop() error
@@ -15,3 +21,10 @@ type Publisher interface {
 	Publish(subject string, content []byte) error
 	Close() error
 }
+
+// ContextPublisher publishes messages with headers and propagates request ID and trace context of ctx.
+// It is declared apart from Publisher not to break its existing implementations.
+type ContextPublisher interface {
+	Publisher
+	PublishContext(ctx context.Context, subject string, content []byte, headers natsbroker.Header) error
+}
@@ -0,0 +1,336 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	natsbroker "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	pullErrorDelay    = 100 * time.Millisecond
+	maxPullErrorDelay = 5 * time.Second
+)
+
+// JetStreamConsumer is a durable JetStream consumer, which processes messages in goroutines pool
+// and acknowledges them according to handler result. Only pull consumers are supported.
+type JetStreamConsumer struct {
+	connection         *natsbroker.Conn
+	jetStream          jetstream.JetStream
+	consumer           jetstream.Consumer
+	iterator           jetstream.MessagesContext
+	messageChannel     chan jetstream.Msg
+	goroutinesPoolSize int
+	options            *jetStreamConsumerOptions
+	state              consumerState
+	mu                 sync.Mutex
+	stopped            chan struct{}
+	wg                 *sync.WaitGroup
+}
+
+// NewJetStreamConsumer creates *JetStreamConsumer for durable consumer with provided name of provided stream.
+// Stream and consumer should exist or should be provisioned via WithStreamConfig and WithConsumerConfig.
+func NewJetStreamConsumer(
+	url string,
+	stream string,
+	name string,
+	opts ...JetStreamConsumerOption,
+) (*JetStreamConsumer, error) {
+	options := newJetStreamConsumerOptions()
+	for _, opt := range opts {
+		err := opt(options)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	connection, err := natsbroker.Connect(url, options.natsOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	jetStream, err := jetstream.New(connection)
+	if err != nil {
+		connection.Close()
+
+		return nil, err
+	}
+
+	consumer, err := provisionConsumer(context.Background(), jetStream, stream, name, options)
+	if err != nil {
+		connection.Close()
+
+		return nil, err
+	}
+
+	return &JetStreamConsumer{
+		connection:         connection,
+		jetStream:          jetStream,
+		consumer:           consumer,
+		messageChannel:     make(chan jetstream.Msg, options.messageChannelBufferSize),
+		goroutinesPoolSize: options.goroutinesPoolSize,
+		options:            options,
+		stopped:            make(chan struct{}),
+		wg:                 new(sync.WaitGroup),
+	}, nil
+}
+
+// provisionConsumer creates or updates stream and consumer, if their configs were provided,
+// and returns consumer with provided name.
+func provisionConsumer(
+	ctx context.Context,
+	jetStream jetstream.JetStream,
+	stream string,
+	name string,
+	options *jetStreamConsumerOptions,
+) (jetstream.Consumer, error) {
+	if options.streamConfig != nil {
+		config := *options.streamConfig
+		if config.Name == "" {
+			config.Name = stream
+		}
+
+		if _, err := EnsureStream(ctx, jetStream, config); err != nil {
+			return nil, err
+		}
+	}
+
+	if options.consumerConfig == nil {
+		return jetStream.Consumer(ctx, stream, name)
+	}
+
+	config := *options.consumerConfig
+	if config.Durable == "" {
+		config.Durable = name
+	}
+
+	return EnsureConsumer(ctx, jetStream, stream, config)
+}
+
+// JetStream returns jetstream.JetStream for streams management and operations, not covered by consumer.
+func (c *JetStreamConsumer) JetStream() jetstream.JetStream {
+	return c.jetStream
+}
+
+// Run starts pulling messages and goroutines for their processing.
+func (c *JetStreamConsumer) Run() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch c.state {
+	case consumerStateRunning:
+		return &ConsumerAlreadyRunningError{}
+	case consumerStateStopped:
+		return &ConsumerAlreadyStoppedError{}
+	}
+
+	iterator, err := c.consumer.Messages(c.options.pullOpts...)
+	if err != nil {
+		return err
+	}
+
+	c.iterator = iterator
+
+	c.wg.Add(c.goroutinesPoolSize)
+
+	for range c.goroutinesPoolSize {
+		go func() {
+			defer c.wg.Done()
+
+			for msg := range c.messageChannel {
+				c.process(msg)
+			}
+		}()
+	}
+
+	go c.pull(iterator)
+
+	c.state = consumerStateRunning
+
+	return nil
+}
+
+// pull receives messages from iterator and sends them for processing until iterator is closed or terminal
+// error occurs. Pulling is retried with backoff after other errors.
+func (c *JetStreamConsumer) pull(iterator jetstream.MessagesContext) {
+	defer close(c.messageChannel)
+
+	var failures uint64
+
+	for {
+		msg, err := iterator.Next()
+
+		switch {
+		case err == nil:
+			failures = 0
+			c.messageChannel <- msg
+
+			continue
+		case errors.Is(err, jetstream.ErrMsgIteratorClosed):
+			return
+		}
+
+		c.options.errorHandler(err)
+
+		if errors.Is(err, natsbroker.ErrConnectionClosed) || errors.Is(err, jetstream.ErrConsumerDeleted) {
+			return
+		}
+
+		failures++
+
+		select {
+		case <-c.stopped:
+			return
+		case <-time.After(backoffDelay(pullErrorDelay, maxPullErrorDelay, failures)):
+		}
+	}
+}
+
+// Stop stops pulling messages, waits for already received messages to be processed and closes connection.
+// If consumer was not run, only connection is closed.
+// Stop is safe to call concurrently with Run and other Stop calls.
+func (c *JetStreamConsumer) Stop() error {
+	c.mu.Lock()
+	if c.state == consumerStateStopped {
+		c.mu.Unlock()
+
+		return &ConsumerAlreadyStoppedError{}
+	}
+
+	wasRunning := c.state == consumerStateRunning
+	c.state = consumerStateStopped
+	c.mu.Unlock()
+
+	close(c.stopped)
+
+	if !wasRunning {
+		c.connection.Close()
+
+		return nil
+	}
+
+	// Drain lets already buffered messages to be received by iterator and closes it afterwards:
+	c.iterator.Drain()
+	c.wg.Wait()
+
+	c.connection.Close()
+
+	return nil
+}
+
+// process handles message and acknowledges it according to handler result.
+func (c *JetStreamConsumer) process(msg jetstream.Msg) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var keeper sync.WaitGroup
+
+	if c.options.inProgressInterval > 0 {
+		keeper.Add(1)
+
+		go func() {
+			defer keeper.Done()
+
+			c.keepInProgress(ctx, msg)
+		}()
+	}
+
+	handlerErr := c.handle(ctx, msg)
+
+	// Progress notifications should not be sent after message acknowledgement:
+	cancel()
+	keeper.Wait()
+
+	var ackErr error
+
+	var (
+		terminateErr *TerminateError
+		panicErr     *HandlerPanicError
+	)
+
+	switch {
+	case handlerErr == nil:
+		ackErr = msg.Ack()
+	case errors.As(handlerErr, &terminateErr), errors.As(handlerErr, &panicErr):
+		// Message, which handler panicked on, is terminated not to be redelivered and panic again:
+		ackErr = msg.TermWithReason(handlerErr.Error())
+	default:
+		ackErr = msg.NakWithDelay(c.nakDelay(msg))
+	}
+
+	if ackErr != nil {
+		c.options.errorHandler(ackErr)
+	}
+}
+
+// handle calls handler, converting its panic to HandlerPanicError, so single message can not crash
+// the whole processing goroutine.
+func (c *JetStreamConsumer) handle(ctx context.Context, msg jetstream.Msg) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = &HandlerPanicError{BaseErr: fmt.Errorf("%v", recovered)}
+		}
+	}()
+
+	return c.options.messageHandler(ctx, msg)
+}
+
+// keepInProgress periodically notifies server, that message is still being processed, until ctx is done.
+func (c *JetStreamConsumer) keepInProgress(ctx context.Context, msg jetstream.Msg) {
+	ticker := time.NewTicker(c.options.inProgressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := msg.InProgress(); err != nil {
+				c.options.errorHandler(err)
+			}
+		}
+	}
+}
+
+// nakDelay calculates redelivery delay for message on base of number of its deliveries.
+func (c *JetStreamConsumer) nakDelay(msg jetstream.Msg) time.Duration {
+	var deliveries uint64 = 1
+	if metadata, err := msg.Metadata(); err == nil {
+		deliveries = metadata.NumDelivered
+	}
+
+	return backoffDelay(c.options.nakDelay, c.options.maxNakDelay, deliveries)
+}
+
+// backoffDelay doubles initial delay for every attempt after first one, limiting it with maxDelay.
+func backoffDelay(initial, maxDelay time.Duration, attempt uint64) time.Duration {
+	delay := initial
+	for i := uint64(1); i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxDelay)
+}
+
+// EnsureStream creates stream with provided config or updates existing one.
+func EnsureStream(
+	ctx context.Context,
+	jetStream jetstream.JetStream,
+	config jetstream.StreamConfig,
+) (jetstream.Stream, error) {
+	return jetStream.CreateOrUpdateStream(ctx, config)
+}
+
+// EnsureConsumer creates durable consumer with provided config for provided stream or updates existing one.
+func EnsureConsumer(
+	ctx context.Context,
+	jetStream jetstream.JetStream,
+	stream string,
+	config jetstream.ConsumerConfig,
+) (jetstream.Consumer, error) {
+	return jetStream.CreateOrUpdateConsumer(ctx, stream, config)
+}
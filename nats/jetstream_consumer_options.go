@@ -0,0 +1,157 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	natsbroker "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	defaultNakDelay    = time.Second
+	defaultMaxNakDelay = time.Minute
+)
+
+var (
+	defaultJetStreamMessageHandler = func(_ context.Context, message jetstream.Msg) error {
+		fmt.Printf("nats jetstream message: %s\n", string(message.Data()))
+
+		return nil
+	}
+
+	defaultJetStreamErrorHandler = func(err error) {
+		fmt.Printf("nats jetstream error: %v\n", err)
+	}
+)
+
+// newJetStreamConsumerOptions creates *jetStreamConsumerOptions with default values.
+func newJetStreamConsumerOptions() *jetStreamConsumerOptions {
+	return &jetStreamConsumerOptions{
+		messageChannelBufferSize: defaultMessageChannelBufferSize,
+		goroutinesPoolSize:       defaultGoroutinesPoolSize,
+		messageHandler:           defaultJetStreamMessageHandler,
+		errorHandler:             defaultJetStreamErrorHandler,
+		nakDelay:                 defaultNakDelay,
+		maxNakDelay:              defaultMaxNakDelay,
+	}
+}
+
+// jetStreamConsumerOptions represents options for JetStreamConsumer configuration.
+type jetStreamConsumerOptions struct {
+	messageChannelBufferSize int
+	goroutinesPoolSize       int
+	messageHandler           func(ctx context.Context, message jetstream.Msg) error
+	errorHandler             func(err error)
+	nakDelay                 time.Duration
+	maxNakDelay              time.Duration
+	inProgressInterval       time.Duration
+	streamConfig             *jetstream.StreamConfig
+	consumerConfig           *jetstream.ConsumerConfig
+	pullOpts                 []jetstream.PullMessagesOpt
+	natsOpts                 []natsbroker.Option
+}
+
+// JetStreamConsumerOption represents golang functional option pattern func for JetStreamConsumer configuration.
+type JetStreamConsumerOption func(options *jetStreamConsumerOptions) error
+
+// WithJetStreamMessageChannelBufferSize sets buffer for channel, where messages are stored for processing.
+func WithJetStreamMessageChannelBufferSize(size int) JetStreamConsumerOption {
+	return func(options *jetStreamConsumerOptions) error {
+		options.messageChannelBufferSize = size
+
+		return nil
+	}
+}
+
+// WithJetStreamGoroutinesPoolSize sets number of goroutines for processing messages.
+func WithJetStreamGoroutinesPoolSize(size int) JetStreamConsumerOption {
+	return func(options *jetStreamConsumerOptions) error {
+		options.goroutinesPoolSize = size
+
+		return nil
+	}
+}
+
+// WithJetStreamMessageHandler sets handler for received message. If handler returns nil, message is acked.
+// If handler returns TerminateError, message is terminated and is not redelivered. Otherwise, message is
+// nacked and redelivered after delay, which grows with number of deliveries.
+func WithJetStreamMessageHandler(
+	handler func(ctx context.Context, message jetstream.Msg) error,
+) JetStreamConsumerOption {
+	return func(options *jetStreamConsumerOptions) error {
+		options.messageHandler = handler
+
+		return nil
+	}
+}
+
+// WithJetStreamErrorHandler sets handler for errors, which occur during messages receiving and acknowledgement.
+func WithJetStreamErrorHandler(handler func(err error)) JetStreamConsumerOption {
+	return func(options *jetStreamConsumerOptions) error {
+		options.errorHandler = handler
+
+		return nil
+	}
+}
+
+// WithNakDelay sets delay before first redelivery of nacked message and its maximum value.
+// Delay is doubled on every next delivery.
+func WithNakDelay(initial, maxDelay time.Duration) JetStreamConsumerOption {
+	return func(options *jetStreamConsumerOptions) error {
+		options.nakDelay = initial
+		options.maxNakDelay = maxDelay
+
+		return nil
+	}
+}
+
+// WithInProgressInterval enables sending of InProgress acknowledgements with provided interval, while message
+// is being processed, not to let server redeliver message of long job. Interval should be less than
+// consumer AckWait.
+func WithInProgressInterval(interval time.Duration) JetStreamConsumerOption {
+	return func(options *jetStreamConsumerOptions) error {
+		options.inProgressInterval = interval
+
+		return nil
+	}
+}
+
+// WithStreamConfig enables provisioning of stream: stream is created or updated with provided config
+// on consumer creation.
+func WithStreamConfig(config jetstream.StreamConfig) JetStreamConsumerOption {
+	return func(options *jetStreamConsumerOptions) error {
+		options.streamConfig = &config
+
+		return nil
+	}
+}
+
+// WithConsumerConfig enables provisioning of durable consumer: consumer is created or updated with provided
+// config on consumer creation. If Durable is empty, consumer name is used.
+func WithConsumerConfig(config jetstream.ConsumerConfig) JetStreamConsumerOption {
+	return func(options *jetStreamConsumerOptions) error {
+		options.consumerConfig = &config
+
+		return nil
+	}
+}
+
+// WithPullOptions sets options for pulling messages from server, such as jetstream.PullMaxMessages.
+func WithPullOptions(opts ...jetstream.PullMessagesOpt) JetStreamConsumerOption {
+	return func(options *jetStreamConsumerOptions) error {
+		options.pullOpts = append(options.pullOpts, opts...)
+
+		return nil
+	}
+}
+
+// WithJetStreamNatsOptions sets NATS option for connection with broker configuration.
+func WithJetStreamNatsOptions(opts ...natsbroker.Option) JetStreamConsumerOption {
+	return func(options *jetStreamConsumerOptions) error {
+		options.natsOpts = append(options.natsOpts, opts...)
+
+		return nil
+	}
+}
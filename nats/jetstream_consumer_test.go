@@ -0,0 +1,262 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	natsbroker "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMsg records acknowledgements of jetstream.Msg.
+type fakeMsg struct {
+	jetstream.Msg
+
+	mu                 sync.Mutex
+	deliveries         uint64
+	acked              bool
+	nakDelay           time.Duration
+	termReason         string
+	inProgresses       int
+	inProgressAfterAck bool
+}
+
+func (m *fakeMsg) Metadata() (*jetstream.MsgMetadata, error) {
+	return &jetstream.MsgMetadata{NumDelivered: m.deliveries}, nil
+}
+
+func (m *fakeMsg) Data() []byte {
+	return []byte("data")
+}
+
+func (m *fakeMsg) Headers() natsbroker.Header {
+	return natsbroker.Header{}
+}
+
+func (m *fakeMsg) Ack() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.acked = true
+
+	return nil
+}
+
+func (m *fakeMsg) NakWithDelay(delay time.Duration) error {
+	m.nakDelay = delay
+
+	return nil
+}
+
+func (m *fakeMsg) TermWithReason(reason string) error {
+	m.termReason = reason
+
+	return nil
+}
+
+func (m *fakeMsg) InProgress() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.inProgresses++
+	m.inProgressAfterAck = m.inProgressAfterAck || m.acked
+
+	return nil
+}
+
+func TestBackoffDelay(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		attempt  uint64
+		expected time.Duration
+	}{
+		{attempt: 0, expected: time.Second},
+		{attempt: 1, expected: time.Second},
+		{attempt: 2, expected: 2 * time.Second},
+		{attempt: 4, expected: 8 * time.Second},
+		{attempt: 100, expected: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, backoffDelay(time.Second, 10*time.Second, tt.attempt))
+	}
+}
+
+func TestJetStreamConsumerProcess(t *testing.T) {
+	t.Parallel()
+
+	newConsumer := func(handler func(ctx context.Context, message jetstream.Msg) error) *JetStreamConsumer {
+		options := newJetStreamConsumerOptions()
+		options.messageHandler = handler
+		options.errorHandler = func(err error) {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		return &JetStreamConsumer{options: options}
+	}
+
+	t.Run("message is acked on success", func(t *testing.T) {
+		t.Parallel()
+
+		msg := &fakeMsg{deliveries: 1}
+		newConsumer(func(context.Context, jetstream.Msg) error { return nil }).process(msg)
+		require.True(t, msg.acked)
+	})
+
+	t.Run("message is nacked with delay on error", func(t *testing.T) {
+		t.Parallel()
+
+		msg := &fakeMsg{deliveries: 3}
+		newConsumer(func(context.Context, jetstream.Msg) error { return errors.New("temporary") }).process(msg)
+		require.False(t, msg.acked)
+		require.Equal(t, 4*defaultNakDelay, msg.nakDelay)
+	})
+
+	t.Run("message is terminated on TerminateError", func(t *testing.T) {
+		t.Parallel()
+
+		msg := &fakeMsg{deliveries: 1}
+		newConsumer(func(context.Context, jetstream.Msg) error {
+			return &TerminateError{Message: "poisoned message"}
+		}).process(msg)
+		require.False(t, msg.acked)
+		require.Equal(t, "poisoned message", msg.termReason)
+	})
+
+	t.Run("message is terminated on handler panic", func(t *testing.T) {
+		t.Parallel()
+
+		msg := &fakeMsg{deliveries: 1}
+		newConsumer(func(context.Context, jetstream.Msg) error {
+			panic("poisoned message")
+		}).process(msg)
+		require.False(t, msg.acked)
+		require.Contains(t, msg.termReason, "poisoned message")
+	})
+
+	t.Run("in progress is sent during long processing", func(t *testing.T) {
+		t.Parallel()
+
+		msg := &fakeMsg{deliveries: 1}
+		consumer := newConsumer(func(context.Context, jetstream.Msg) error {
+			time.Sleep(50 * time.Millisecond)
+
+			return nil
+		})
+		consumer.options.inProgressInterval = 10 * time.Millisecond
+		consumer.process(msg)
+
+		msg.mu.Lock()
+		defer msg.mu.Unlock()
+
+		require.True(t, msg.acked)
+		require.Positive(t, msg.inProgresses)
+		require.False(t, msg.inProgressAfterAck)
+	})
+}
+
+// fakeIterator is a jetstream.MessagesContext, which returns prepared results of Next.
+type fakeIterator struct {
+	jetstream.MessagesContext
+
+	results []error
+}
+
+func (i *fakeIterator) Next() (jetstream.Msg, error) {
+	if len(i.results) == 0 {
+		return nil, jetstream.ErrMsgIteratorClosed
+	}
+
+	err := i.results[0]
+	i.results = i.results[1:]
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &fakeMsg{deliveries: 1}, nil
+}
+
+func TestJetStreamConsumerPull(t *testing.T) {
+	t.Parallel()
+
+	newConsumer := func(errs *[]error) *JetStreamConsumer {
+		options := newJetStreamConsumerOptions()
+		options.errorHandler = func(err error) {
+			*errs = append(*errs, err)
+		}
+
+		return &JetStreamConsumer{
+			options:        options,
+			messageChannel: make(chan jetstream.Msg, 10),
+			stopped:        make(chan struct{}),
+		}
+	}
+
+	t.Run("pulling is retried after error", func(t *testing.T) {
+		t.Parallel()
+
+		var errs []error
+
+		consumer := newConsumer(&errs)
+		temporaryErr := errors.New("temporary")
+		consumer.pull(&fakeIterator{results: []error{nil, temporaryErr, nil}})
+
+		require.Len(t, consumer.messageChannel, 2)
+		require.Equal(t, []error{temporaryErr}, errs)
+	})
+
+	t.Run("pulling is stopped on terminal error", func(t *testing.T) {
+		t.Parallel()
+
+		var errs []error
+
+		consumer := newConsumer(&errs)
+		consumer.pull(&fakeIterator{results: []error{nil, jetstream.ErrConsumerDeleted, nil}})
+
+		require.Len(t, consumer.messageChannel, 1)
+		require.Equal(t, []error{jetstream.ErrConsumerDeleted}, errs)
+
+		_, ok := <-consumer.messageChannel
+		require.True(t, ok)
+
+		_, ok = <-consumer.messageChannel
+		require.False(t, ok)
+	})
+
+	t.Run("backoff is interrupted by stop", func(t *testing.T) {
+		t.Parallel()
+
+		var errs []error
+
+		consumer := newConsumer(&errs)
+		close(consumer.stopped)
+		consumer.pull(&fakeIterator{results: []error{errors.New("temporary"), nil}})
+
+		require.Empty(t, consumer.messageChannel)
+		require.Len(t, errs, 1)
+	})
+}
+
+func TestJetStreamConsumerStopBeforeRun(t *testing.T) {
+	t.Parallel()
+
+	// Connection is established in background, so it is created without running server:
+	connection, err := natsbroker.Connect(
+		"nats://127.0.0.1:1",
+		natsbroker.RetryOnFailedConnect(true),
+		natsbroker.ReconnectWait(time.Hour),
+	)
+	require.NoError(t, err)
+
+	consumer := &JetStreamConsumer{connection: connection, stopped: make(chan struct{})}
+	require.NoError(t, consumer.Stop())
+	require.True(t, connection.IsClosed())
+	require.IsType(t, &ConsumerAlreadyStoppedError{}, consumer.Stop())
+	require.IsType(t, &ConsumerAlreadyStoppedError{}, consumer.Run())
+}
@@ -0,0 +1,78 @@
+package nats
+
+import (
+	"context"
+
+	natsbroker "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// JetStreamPublisher publishes messages to JetStream streams and waits for acknowledgement of persistence.
+type JetStreamPublisher struct {
+	connection *natsbroker.Conn
+	jetStream  jetstream.JetStream
+}
+
+// NewJetStreamPublisher creates *JetStreamPublisher.
+func NewJetStreamPublisher(url string, opts ...natsbroker.Option) (*JetStreamPublisher, error) {
+	connection, err := natsbroker.Connect(url, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	jetStream, err := jetstream.New(connection)
+	if err != nil {
+		connection.Close()
+
+		return nil, err
+	}
+
+	return &JetStreamPublisher{
+		connection: connection,
+		jetStream:  jetStream,
+	}, nil
+}
+
+// JetStream returns jetstream.JetStream for streams management and operations, not covered by publisher.
+func (p *JetStreamPublisher) JetStream() jetstream.JetStream {
+	return p.jetStream
+}
+
+// Publish sends message to provided topic (subject) and waits for acknowledgement from stream.
+func (p *JetStreamPublisher) Publish(topic string, data []byte) error {
+	_, err := p.jetStream.Publish(context.Background(), topic, data)
+
+	return err
+}
+
+// PublishContext sends message with provided headers to provided subject and waits for acknowledgement
+// from stream. Request ID and W3C trace context of ctx are added to headers.
+func (p *JetStreamPublisher) PublishContext(
+	ctx context.Context,
+	subject string,
+	data []byte,
+	headers natsbroker.Header,
+) error {
+	_, err := p.jetStream.PublishMsg(ctx, newMessage(ctx, subject, data, headers))
+
+	return err
+}
+
+// PublishWithID sends message with provided ID to provided topic (subject) and returns acknowledgement
+// from stream. Message ID is sent in Nats-Msg-Id header, so messages with the same ID, published within
+// stream duplicates window, are stored only once. PubAck.Duplicate reports, whether message was a duplicate.
+func (p *JetStreamPublisher) PublishWithID(
+	ctx context.Context,
+	topic string,
+	messageID string,
+	data []byte,
+) (*jetstream.PubAck, error) {
+	return p.jetStream.Publish(ctx, topic, data, jetstream.WithMsgID(messageID))
+}
+
+// Close closes NATS connection.
+func (p *JetStreamPublisher) Close() error {
+	p.connection.Close()
+
+	return nil
+}
@@ -0,0 +1,93 @@
+//go:build integration
+
+package nats
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	jetStreamName    = "TEST"
+	jetStreamSubject = "test.jetstream"
+	durableName      = "test-durable"
+)
+
+func TestJetStream(t *testing.T) {
+	ctx := context.Background()
+
+	publisher, err := NewJetStreamPublisher(url)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, publisher.Close())
+	}()
+
+	// Stream and durable consumer of previous runs are removed, because server can persist them:
+	err = publisher.JetStream().DeleteStream(ctx, jetStreamName)
+	if !errors.Is(err, jetstream.ErrStreamNotFound) {
+		require.NoError(t, err)
+	}
+
+	_, err = EnsureStream(
+		ctx,
+		publisher.JetStream(),
+		jetstream.StreamConfig{Name: jetStreamName, Subjects: []string{jetStreamSubject}},
+	)
+	require.NoError(t, err)
+
+	t.Run("duplicates are detected", func(t *testing.T) {
+		ack, err := publisher.PublishWithID(ctx, jetStreamSubject, "message-1", []byte("first"))
+		require.NoError(t, err)
+		require.False(t, ack.Duplicate)
+
+		ack, err = publisher.PublishWithID(ctx, jetStreamSubject, "message-1", []byte("first"))
+		require.NoError(t, err)
+		require.True(t, ack.Duplicate)
+	})
+
+	t.Run("failed message is redelivered", func(t *testing.T) {
+		var attempts atomic.Int32
+
+		processed := make(chan struct{})
+		consumer, err := NewJetStreamConsumer(
+			url,
+			jetStreamName,
+			durableName,
+			WithConsumerConfig(jetstream.ConsumerConfig{
+				AckPolicy:     jetstream.AckExplicitPolicy,
+				FilterSubject: jetStreamSubject,
+				DeliverPolicy: jetstream.DeliverNewPolicy,
+			}),
+			WithNakDelay(10*time.Millisecond, 100*time.Millisecond),
+			WithJetStreamMessageHandler(func(_ context.Context, _ jetstream.Msg) error {
+				if attempts.Add(1) == 1 {
+					return context.DeadlineExceeded
+				}
+
+				close(processed)
+
+				return nil
+			}),
+		)
+		require.NoError(t, err)
+		require.NoError(t, consumer.Run())
+
+		require.NoError(t, publisher.Publish(jetStreamSubject, []byte("second")))
+
+		select {
+		case <-processed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("message was not redelivered")
+		}
+
+		require.NoError(t, consumer.Stop())
+		require.Equal(t, int32(2), attempts.Load())
+	})
+}
@@ -0,0 +1,235 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// KeyValueOperation represents type of key-value bucket change.
+type KeyValueOperation string
+
+// Key-value bucket operations.
+const (
+	KeyValueOperationPut    KeyValueOperation = "put"
+	KeyValueOperationDelete KeyValueOperation = "delete"
+	KeyValueOperationPurge  KeyValueOperation = "purge"
+)
+
+// KeyValueEntry represents revision of key in key-value bucket.
+type KeyValueEntry struct {
+	Bucket    string
+	Key       string
+	Value     []byte
+	Revision  uint64
+	Created   time.Time
+	Operation KeyValueOperation
+}
+
+// Decode decodes entry value to v with provided codec.
+func (e *KeyValueEntry) Decode(codec Codec, v any) error {
+	return codec.Unmarshal(e.Value, v)
+}
+
+// newKeyValueEntry converts jetstream.KeyValueEntry to *KeyValueEntry.
+func newKeyValueEntry(entry jetstream.KeyValueEntry) *KeyValueEntry {
+	operation := KeyValueOperationPut
+
+	switch entry.Operation() {
+	case jetstream.KeyValueDelete:
+		operation = KeyValueOperationDelete
+	case jetstream.KeyValuePurge:
+		operation = KeyValueOperationPurge
+	case jetstream.KeyValuePut:
+	}
+
+	return &KeyValueEntry{
+		Bucket:    entry.Bucket(),
+		Key:       entry.Key(),
+		Value:     entry.Value(),
+		Revision:  entry.Revision(),
+		Created:   entry.Created(),
+		Operation: operation,
+	}
+}
+
+// KeyValueStore is a JetStream key-value bucket, which can be used for configuration, feature flags
+// and other small values, which should be shared between services.
+type KeyValueStore struct {
+	kv jetstream.KeyValue
+}
+
+// NewKeyValueStore creates bucket with provided config or updates existing one and returns *KeyValueStore for it.
+func NewKeyValueStore(
+	ctx context.Context,
+	jetStream jetstream.JetStream,
+	config jetstream.KeyValueConfig,
+) (*KeyValueStore, error) {
+	kv, err := jetStream.CreateOrUpdateKeyValue(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyValueStore{kv: kv}, nil
+}
+
+// KeyValue returns jetstream.KeyValue for operations, not covered by store.
+func (s *KeyValueStore) KeyValue() jetstream.KeyValue {
+	return s.kv
+}
+
+// Get returns the last revision of key. Returns *NotFoundError, if key does not exist or was deleted.
+func (s *KeyValueStore) Get(ctx context.Context, key string) (*KeyValueEntry, error) {
+	entry, err := s.kv.Get(ctx, key)
+	if err != nil {
+		return nil, keyValueError(key, err)
+	}
+
+	return newKeyValueEntry(entry), nil
+}
+
+// Put sets value of key regardless of its current revision. Returns new revision.
+func (s *KeyValueStore) Put(ctx context.Context, key string, value []byte) (uint64, error) {
+	revision, err := s.kv.Put(ctx, key, value)
+	if err != nil {
+		return 0, keyValueError(key, err)
+	}
+
+	return revision, nil
+}
+
+// Create sets value of key only if key does not exist or was deleted. Returns *KeyExistsError otherwise.
+func (s *KeyValueStore) Create(ctx context.Context, key string, value []byte) (uint64, error) {
+	revision, err := s.kv.Create(ctx, key, value)
+	if isWrongLastSequenceError(err) {
+		return 0, &KeyExistsError{Message: "key " + key + " already exists", BaseErr: err}
+	}
+
+	if err != nil {
+		return 0, keyValueError(key, err)
+	}
+
+	return revision, nil
+}
+
+// Update sets value of key only if its current revision equals provided one (compare-and-swap).
+// Returns *RevisionMismatchError, if key was changed after provided revision.
+func (s *KeyValueStore) Update(ctx context.Context, key string, value []byte, revision uint64) (uint64, error) {
+	newRevision, err := s.kv.Update(ctx, key, value, revision)
+	if isWrongLastSequenceError(err) {
+		return 0, &RevisionMismatchError{Message: "revision of key " + key + " does not match", BaseErr: err}
+	}
+
+	if err != nil {
+		return 0, keyValueError(key, err)
+	}
+
+	return newRevision, nil
+}
+
+// Delete deletes key. History of key is kept until it is purged.
+func (s *KeyValueStore) Delete(ctx context.Context, key string) error {
+	return keyValueError(key, s.kv.Delete(ctx, key))
+}
+
+// History returns all stored revisions of key from the oldest to the newest.
+// Returns *NotFoundError, if key has no revisions.
+func (s *KeyValueStore) History(ctx context.Context, key string) ([]KeyValueEntry, error) {
+	history, err := s.kv.History(ctx, key)
+	if err != nil {
+		return nil, keyValueError(key, err)
+	}
+
+	entries := make([]KeyValueEntry, 0, len(history))
+	for _, entry := range history {
+		entries = append(entries, *newKeyValueEntry(entry))
+	}
+
+	return entries, nil
+}
+
+// Keys returns all keys of bucket, which were not deleted.
+func (s *KeyValueStore) Keys(ctx context.Context) ([]string, error) {
+	keys, err := s.kv.Keys(ctx)
+	if errors.Is(err, jetstream.ErrNoKeysFound) {
+		return []string{}, nil
+	}
+
+	return keys, err
+}
+
+// Watch watches changes of keys, matching provided pattern, which can contain wildcards. Current values
+// of keys are sent first, unless jetstream.UpdatesOnly option is provided. Watching lasts until ctx is done
+// or KeyValueWatcher is stopped.
+func (s *KeyValueStore) Watch(ctx context.Context, keys string, opts ...jetstream.WatchOpt) (*KeyValueWatcher, error) {
+	watcher, err := s.kv.Watch(ctx, keys, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(chan KeyValueEntry)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(updates)
+
+		for entry := range watcher.Updates() {
+			// Nil entry marks, that all current values were sent:
+			if entry == nil {
+				continue
+			}
+
+			select {
+			case updates <- *newKeyValueEntry(entry):
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return &KeyValueWatcher{watcher: watcher, updates: updates, done: done}, nil
+}
+
+// KeyValueWatcher delivers changes of watched keys.
+type KeyValueWatcher struct {
+	watcher  jetstream.KeyWatcher
+	updates  chan KeyValueEntry
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// Updates returns channel of key changes. Channel is closed, when watcher is stopped.
+func (w *KeyValueWatcher) Updates() <-chan KeyValueEntry {
+	return w.updates
+}
+
+// Stop stops watching. Updates, which were not read yet, are discarded.
+func (w *KeyValueWatcher) Stop() error {
+	w.stopOnce.Do(func() {
+		close(w.done)
+	})
+
+	return w.watcher.Stop()
+}
+
+// keyValueError converts errors of missing keys to *NotFoundError.
+func keyValueError(key string, err error) error {
+	if errors.Is(err, jetstream.ErrKeyNotFound) || errors.Is(err, jetstream.ErrKeyDeleted) {
+		return &NotFoundError{Message: "key " + key + " not found", BaseErr: err}
+	}
+
+	return err
+}
+
+// isWrongLastSequenceError checks, whether write was rejected, because expected revision of key did not match.
+// Server returns the same error for Create of existing key and for Update with outdated revision.
+func isWrongLastSequenceError(err error) bool {
+	var apiErr *jetstream.APIError
+
+	return errors.As(err, &apiErr) && apiErr.ErrorCode == jetstream.JSErrCodeStreamWrongLastSequence
+}
@@ -0,0 +1,178 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEntry is a jetstream.KeyValueEntry with fixed values.
+type fakeEntry struct {
+	jetstream.KeyValueEntry
+
+	key       string
+	value     []byte
+	revision  uint64
+	operation jetstream.KeyValueOp
+}
+
+func (e *fakeEntry) Bucket() string                  { return "bucket" }
+func (e *fakeEntry) Key() string                     { return e.key }
+func (e *fakeEntry) Value() []byte                   { return e.value }
+func (e *fakeEntry) Revision() uint64                { return e.revision }
+func (e *fakeEntry) Created() time.Time              { return time.Time{} }
+func (e *fakeEntry) Operation() jetstream.KeyValueOp { return e.operation }
+
+// fakeWatcher is a jetstream.KeyWatcher, which sends prepared entries.
+type fakeWatcher struct {
+	updates chan jetstream.KeyValueEntry
+}
+
+func (w *fakeWatcher) Updates() <-chan jetstream.KeyValueEntry { return w.updates }
+
+func (w *fakeWatcher) Stop() error {
+	close(w.updates)
+
+	return nil
+}
+
+// fakeKeyValue is a jetstream.KeyValue, which returns prepared errors and entries.
+type fakeKeyValue struct {
+	jetstream.KeyValue
+
+	err     error
+	watcher *fakeWatcher
+}
+
+func (kv *fakeKeyValue) Get(context.Context, string) (jetstream.KeyValueEntry, error) {
+	return nil, kv.err
+}
+
+func (kv *fakeKeyValue) Create(context.Context, string, []byte) (uint64, error) {
+	return 0, kv.err
+}
+
+func (kv *fakeKeyValue) Update(context.Context, string, []byte, uint64) (uint64, error) {
+	return 0, kv.err
+}
+
+func (kv *fakeKeyValue) Keys(context.Context, ...jetstream.WatchOpt) ([]string, error) {
+	return nil, kv.err
+}
+
+func (kv *fakeKeyValue) Watch(context.Context, string, ...jetstream.WatchOpt) (jetstream.KeyWatcher, error) {
+	return kv.watcher, kv.err
+}
+
+func TestKeyValueStoreErrors(t *testing.T) {
+	t.Parallel()
+
+	wrongSequenceErr := &jetstream.APIError{ErrorCode: jetstream.JSErrCodeStreamWrongLastSequence, Code: 400}
+	ctx := context.Background()
+
+	t.Run("missing key", func(t *testing.T) {
+		t.Parallel()
+
+		for _, baseErr := range []error{jetstream.ErrKeyNotFound, jetstream.ErrKeyDeleted} {
+			store := &KeyValueStore{kv: &fakeKeyValue{err: baseErr}}
+
+			_, err := store.Get(ctx, "flag")
+			require.IsType(t, &NotFoundError{}, err)
+			require.ErrorIs(t, err, baseErr)
+		}
+	})
+
+	t.Run("create existing key", func(t *testing.T) {
+		t.Parallel()
+
+		// Create wraps server error in the same way as jetstream does:
+		store := &KeyValueStore{kv: &fakeKeyValue{err: fmt.Errorf("%w: key exists", wrongSequenceErr)}}
+
+		_, err := store.Create(ctx, "flag", []byte("on"))
+		require.IsType(t, &KeyExistsError{}, err)
+	})
+
+	t.Run("update with outdated revision", func(t *testing.T) {
+		t.Parallel()
+
+		store := &KeyValueStore{kv: &fakeKeyValue{err: wrongSequenceErr}}
+
+		_, err := store.Update(ctx, "flag", []byte("on"), 1)
+		require.IsType(t, &RevisionMismatchError{}, err)
+	})
+
+	t.Run("empty bucket has no keys", func(t *testing.T) {
+		t.Parallel()
+
+		store := &KeyValueStore{kv: &fakeKeyValue{err: jetstream.ErrNoKeysFound}}
+
+		keys, err := store.Keys(ctx)
+		require.NoError(t, err)
+		require.Empty(t, keys)
+	})
+
+	t.Run("missing object", func(t *testing.T) {
+		t.Parallel()
+
+		err := objectError("file", jetstream.ErrObjectNotFound)
+		require.IsType(t, &NotFoundError{}, err)
+		require.NoError(t, objectError("file", nil))
+	})
+}
+
+func TestKeyValueStoreWatch(t *testing.T) {
+	t.Parallel()
+
+	watcher := &fakeWatcher{updates: make(chan jetstream.KeyValueEntry, 3)}
+	watcher.updates <- &fakeEntry{key: "flag", value: []byte("on"), revision: 1}
+	watcher.updates <- nil
+	watcher.updates <- &fakeEntry{key: "flag", revision: 2, operation: jetstream.KeyValueDelete}
+
+	store := &KeyValueStore{kv: &fakeKeyValue{watcher: watcher}}
+
+	keyWatcher, err := store.Watch(context.Background(), "flag")
+	require.NoError(t, err)
+
+	update := <-keyWatcher.Updates()
+	require.Equal(t, "flag", update.Key)
+	require.Equal(t, uint64(1), update.Revision)
+	require.Equal(t, KeyValueOperationPut, update.Operation)
+
+	var value string
+	require.NoError(t, (&KeyValueEntry{Value: []byte(`"on"`)}).Decode(JSONCodec{}, &value))
+	require.Equal(t, "on", value)
+
+	// Marker of initial values end is skipped:
+	update = <-keyWatcher.Updates()
+	require.Equal(t, uint64(2), update.Revision)
+	require.Equal(t, KeyValueOperationDelete, update.Operation)
+
+	require.NoError(t, keyWatcher.Stop())
+
+	_, ok := <-keyWatcher.Updates()
+	require.False(t, ok)
+}
+
+func TestKeyValueWatcherStopWithoutReading(t *testing.T) {
+	t.Parallel()
+
+	watcher := &fakeWatcher{updates: make(chan jetstream.KeyValueEntry, 1)}
+	watcher.updates <- &fakeEntry{key: "flag", revision: 1}
+
+	store := &KeyValueStore{kv: &fakeKeyValue{watcher: watcher}}
+
+	keyWatcher, err := store.Watch(context.Background(), "flag")
+	require.NoError(t, err)
+
+	// Forwarding goroutine takes update and waits for reader:
+	require.Eventually(t, func() bool { return len(watcher.updates) == 0 }, time.Second, time.Millisecond)
+
+	require.NoError(t, keyWatcher.Stop())
+
+	_, ok := <-keyWatcher.Updates()
+	require.False(t, ok)
+}
@@ -0,0 +1,213 @@
+package nats
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	natsbroker "github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	subjectLabel    = "subject"
+	statusLabel     = "status"
+	connectionLabel = "connection"
+
+	statusOK    = "ok"
+	statusError = "error"
+)
+
+var (
+	// publishedMessages PROMQL => rate(nats_published_messages_total[30s]).
+	publishedMessages = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nats_published_messages_total",
+			Help: "Number of messages, published to NATS.",
+		},
+		[]string{subjectLabel},
+	)
+
+	publishedBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nats_published_bytes_total",
+			Help: "Size of messages data, published to NATS.",
+		},
+		[]string{subjectLabel},
+	)
+
+	publishErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nats_publish_errors_total",
+			Help: "Number of failed publications to NATS.",
+		},
+		[]string{subjectLabel},
+	)
+
+	// consumedMessages PROMQL => rate(nats_consumed_messages_total[30s]).
+	consumedMessages = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nats_consumed_messages_total",
+			Help: "Number of messages, received by consumer. Subject is a subject of subscription.",
+		},
+		[]string{subjectLabel},
+	)
+
+	// handlerDuration PROMQL => rate(nats_handler_duration_seconds_sum[30s]) /
+	// rate(nats_handler_duration_seconds_count[30s]).
+	handlerDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "nats_handler_duration_seconds",
+			Help: "Execution time of single message handler call.",
+		},
+		[]string{subjectLabel, statusLabel},
+	)
+
+	handlerErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nats_handler_errors_total",
+			Help: "Number of failed message handler calls, including panics.",
+		},
+		[]string{subjectLabel},
+	)
+
+	bufferedMessages = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nats_consumer_buffered_messages",
+			Help: "Number of messages, waiting for processing in message channels of consumers with the same connection name.",
+		},
+		[]string{connectionLabel},
+	)
+
+	bufferSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nats_consumer_buffer_size",
+			Help: "Total size of message channel buffers of consumers with the same connection name.",
+		},
+		[]string{connectionLabel},
+	)
+
+	slowConsumerEvents = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nats_slow_consumer_events_total",
+			Help: "Number of messages, dropped by NATS, because consumer did not keep up with them.",
+		},
+		[]string{subjectLabel},
+	)
+
+	disconnects = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nats_disconnects_total",
+			Help: "Number of disconnections from NATS server.",
+		},
+		[]string{connectionLabel},
+	)
+
+	reconnects = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nats_reconnects_total",
+			Help: "Number of reconnections to NATS server.",
+		},
+		[]string{connectionLabel},
+	)
+
+	registerMetricsOnce sync.Once
+)
+
+// registerMetrics registers NATS metrics in default Prometheus registry. Metrics are registered only
+// on first call, so they are not exported by applications, which do not enable them.
+func registerMetrics() {
+	registerMetricsOnce.Do(func() {
+		prometheus.MustRegister(publishedMessages)
+		prometheus.MustRegister(publishedBytes)
+		prometheus.MustRegister(publishErrors)
+		prometheus.MustRegister(consumedMessages)
+		prometheus.MustRegister(handlerDuration)
+		prometheus.MustRegister(handlerErrors)
+		prometheus.MustRegister(bufferedMessages)
+		prometheus.MustRegister(bufferSize)
+		prometheus.MustRegister(slowConsumerEvents)
+		prometheus.MustRegister(disconnects)
+		prometheus.MustRegister(reconnects)
+	})
+}
+
+// instrumentConnection registers metrics and wraps connection handlers for counting disconnects,
+// reconnects and slow consumer events. Handlers, which were already set, are still called.
+// Connection name, set via natsbroker.Name, is used as a label.
+func instrumentConnection(connection *natsbroker.Conn) {
+	registerMetrics()
+
+	labels := prometheus.Labels{connectionLabel: connection.Opts.Name}
+
+	disconnectHandler := connection.Opts.DisconnectedErrCB
+	connection.SetDisconnectErrHandler(func(conn *natsbroker.Conn, err error) {
+		disconnects.With(labels).Inc()
+
+		if disconnectHandler != nil {
+			disconnectHandler(conn, err)
+		}
+	})
+
+	reconnectHandler := connection.Opts.ReconnectedCB
+	connection.SetReconnectHandler(func(conn *natsbroker.Conn) {
+		reconnects.With(labels).Inc()
+
+		if reconnectHandler != nil {
+			reconnectHandler(conn)
+		}
+	})
+
+	errorHandler := connection.Opts.AsyncErrorCB
+	connection.SetErrorHandler(func(conn *natsbroker.Conn, subscription *natsbroker.Subscription, err error) {
+		if errors.Is(err, natsbroker.ErrSlowConsumer) {
+			slowConsumerEvents.With(prometheus.Labels{subjectLabel: subscriptionSubject(subscription)}).Inc()
+		}
+
+		if errorHandler != nil {
+			errorHandler(conn, subscription, err)
+		}
+	})
+}
+
+// observePublish records metrics of message publication to provided subject. If normalizer is provided,
+// subject is normalized before being used as a label.
+func observePublish(normalizer SubjectNormalizer, subject string, size int, err error) {
+	if normalizer != nil {
+		subject = normalizer(subject)
+	}
+
+	labels := prometheus.Labels{subjectLabel: subject}
+	if err != nil {
+		publishErrors.With(labels).Inc()
+
+		return
+	}
+
+	publishedMessages.With(labels).Inc()
+	publishedBytes.With(labels).Add(float64(size))
+}
+
+// observeHandler records metrics of single handler call.
+func observeHandler(msg *natsbroker.Msg, duration time.Duration, err error) {
+	subject := subscriptionSubject(msg.Sub)
+	status := statusOK
+
+	if err != nil {
+		status = statusError
+
+		handlerErrors.With(prometheus.Labels{subjectLabel: subject}).Inc()
+	}
+
+	handlerDuration.With(prometheus.Labels{subjectLabel: subject, statusLabel: status}).Observe(duration.Seconds())
+}
+
+// subscriptionSubject returns subject of subscription. Subject of subscription is used as a label instead of
+// message subject, because it can contain wildcards and does not increase metrics cardinality.
+func subscriptionSubject(subscription *natsbroker.Subscription) string {
+	if subscription == nil {
+		return ""
+	}
+
+	return subscription.Subject
+}
@@ -0,0 +1,130 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	natsbroker "github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObservePublish(t *testing.T) {
+	t.Parallel()
+
+	labels := prometheus.Labels{subjectLabel: "metrics.publish"}
+
+	observePublish(nil, "metrics.publish", 10, nil)
+	observePublish(nil, "metrics.publish", 5, nil)
+	observePublish(nil, "metrics.publish", 5, errors.New("publish error"))
+
+	require.InDelta(t, 2, testutil.ToFloat64(publishedMessages.With(labels)), 0)
+	require.InDelta(t, 15, testutil.ToFloat64(publishedBytes.With(labels)), 0)
+	require.InDelta(t, 1, testutil.ToFloat64(publishErrors.With(labels)), 0)
+
+	normalizer := func(subject string) string {
+		return strings.Join(append(strings.Split(subject, ".")[:2], "*"), ".")
+	}
+
+	observePublish(normalizer, "metrics.normalized.1", 1, nil)
+	observePublish(normalizer, "metrics.normalized.2", 1, nil)
+
+	require.InDelta(
+		t,
+		2,
+		testutil.ToFloat64(publishedMessages.With(prometheus.Labels{subjectLabel: "metrics.normalized.*"})),
+		0,
+	)
+}
+
+func TestCommonConsumerObserveBuffered(t *testing.T) {
+	t.Parallel()
+
+	connection := &natsbroker.Conn{}
+	connection.Opts.Name = "metrics-buffered"
+	labels := prometheus.Labels{connectionLabel: "metrics-buffered"}
+
+	first := &CommonConsumer{connection: connection}
+	second := &CommonConsumer{connection: connection}
+
+	first.observeBuffered(3)
+	second.observeBuffered(2)
+	require.InDelta(t, 5, testutil.ToFloat64(bufferedMessages.With(labels)), 0)
+
+	first.observeBuffered(1)
+	require.InDelta(t, 3, testutil.ToFloat64(bufferedMessages.With(labels)), 0)
+
+	second.observeBuffered(0)
+	require.InDelta(t, 1, testutil.ToFloat64(bufferedMessages.With(labels)), 0)
+}
+
+func TestCommonConsumerHandlerMetrics(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	consumer := &CommonConsumer{
+		metricsEnabled: true,
+		handler: func(context.Context, *natsbroker.Msg) error {
+			calls++
+			if calls == 1 {
+				panic("boom")
+			}
+
+			return nil
+		},
+	}
+
+	msg := natsbroker.NewMsg("metrics.handler.1")
+	msg.Sub = &natsbroker.Subscription{Subject: "metrics.handler.*"}
+
+	require.Error(t, consumer.handle(context.Background(), msg))
+	require.NoError(t, consumer.handle(context.Background(), msg))
+
+	require.InDelta(
+		t,
+		1,
+		testutil.ToFloat64(handlerErrors.With(prometheus.Labels{subjectLabel: "metrics.handler.*"})),
+		0,
+	)
+	require.Equal(t, 2, testutil.CollectAndCount(handlerDuration, "nats_handler_duration_seconds"))
+}
+
+func TestInstrumentConnection(t *testing.T) {
+	t.Parallel()
+
+	var (
+		disconnectCalled bool
+		errorCalled      bool
+	)
+
+	connection := &natsbroker.Conn{}
+	connection.Opts.Name = "metrics-connection"
+	connection.SetDisconnectErrHandler(func(*natsbroker.Conn, error) { disconnectCalled = true })
+	connection.SetErrorHandler(func(*natsbroker.Conn, *natsbroker.Subscription, error) { errorCalled = true })
+
+	instrumentConnection(connection)
+
+	connection.Opts.DisconnectedErrCB(connection, nil)
+	connection.Opts.ReconnectedCB(connection)
+	connection.Opts.AsyncErrorCB(
+		connection,
+		&natsbroker.Subscription{Subject: "metrics.slow"},
+		natsbroker.ErrSlowConsumer,
+	)
+
+	require.True(t, disconnectCalled)
+	require.True(t, errorCalled)
+
+	labels := prometheus.Labels{connectionLabel: "metrics-connection"}
+	require.InDelta(t, 1, testutil.ToFloat64(disconnects.With(labels)), 0)
+	require.InDelta(t, 1, testutil.ToFloat64(reconnects.With(labels)), 0)
+	require.InDelta(
+		t,
+		1,
+		testutil.ToFloat64(slowConsumerEvents.With(prometheus.Labels{subjectLabel: "metrics.slow"})),
+		0,
+	)
+}
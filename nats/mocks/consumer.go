@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=interfaces.go -destination=mocks/consumer.go -package=mocks -exclude_interfaces=Publisher
+//	mockgen -source=interfaces.go -destination=mocks/consumer.go -package=mocks -exclude_interfaces=Publisher,ContextPublisher
 //
 
 // Package mocks is a generated GoMock package.
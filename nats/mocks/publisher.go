@@ -10,8 +10,10 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
+	nats "github.com/nats-io/nats.go"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -66,3 +68,69 @@ func (mr *MockPublisherMockRecorder) Publish(subject, content any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockPublisher)(nil).Publish), subject, content)
 }
+
+// MockContextPublisher is a mock of ContextPublisher interface.
+type MockContextPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockContextPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockContextPublisherMockRecorder is the mock recorder for MockContextPublisher.
+type MockContextPublisherMockRecorder struct {
+	mock *MockContextPublisher
+}
+
+// NewMockContextPublisher creates a new mock instance.
+func NewMockContextPublisher(ctrl *gomock.Controller) *MockContextPublisher {
+	mock := &MockContextPublisher{ctrl: ctrl}
+	mock.recorder = &MockContextPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContextPublisher) EXPECT() *MockContextPublisherMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockContextPublisher) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockContextPublisherMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockContextPublisher)(nil).Close))
+}
+
+// Publish mocks base method.
+func (m *MockContextPublisher) Publish(subject string, content []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", subject, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockContextPublisherMockRecorder) Publish(subject, content any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockContextPublisher)(nil).Publish), subject, content)
+}
+
+// PublishContext mocks base method.
+func (m *MockContextPublisher) PublishContext(ctx context.Context, subject string, content []byte, headers nats.Header) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishContext", ctx, subject, content, headers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishContext indicates an expected call of PublishContext.
+func (mr *MockContextPublisherMockRecorder) PublishContext(ctx, subject, content, headers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishContext", reflect.TypeOf((*MockContextPublisher)(nil).PublishContext), ctx, subject, content, headers)
+}
@@ -0,0 +1,7 @@
+// Package natstest provides tools for testing code, which works with NATS broker, without external broker.
+// StartServer starts embedded NATS server on random local port, Recorder awaits messages on subject.
+//
+// Package is a separate module, so embedded NATS server is added to module graph only of its importers:
+//
+//	go get github.com/DKhorkov/libs/nats/natstest
+package natstest
@@ -0,0 +1,56 @@
+package natstest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	natsbroker "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	server := StartServer(t)
+	recorder := Subscribe(t, server.URL(), "test.>")
+
+	connection, err := natsbroker.Connect(server.URL())
+	require.NoError(t, err)
+
+	defer connection.Close()
+
+	require.NoError(t, connection.Publish("test.first", []byte("first")))
+	require.NoError(t, connection.Publish("test.second", []byte("second")))
+
+	messages := recorder.Await(t, 2, time.Second)
+	require.Equal(t, "first", string(messages[0].Data))
+	require.Equal(t, "second", string(messages[1].Data))
+	recorder.AssertNoMessages(t, 10*time.Millisecond)
+}
+
+func TestServerWithJetStream(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := StartServer(t, WithJetStream())
+
+	connection, err := natsbroker.Connect(server.URL())
+	require.NoError(t, err)
+
+	defer connection.Close()
+
+	js, err := jetstream.New(connection)
+	require.NoError(t, err)
+
+	_, err = js.CreateStream(ctx, jetstream.StreamConfig{Name: "TEST", Subjects: []string{"test.js"}})
+	require.NoError(t, err)
+
+	recorder := Subscribe(t, server.URL(), "test.js")
+
+	ack, err := js.Publish(ctx, "test.js", []byte("message"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), ack.Sequence)
+	require.Equal(t, "message", string(recorder.AwaitMessage(t, time.Second).Data))
+}
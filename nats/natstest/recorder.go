@@ -0,0 +1,82 @@
+package natstest
+
+import (
+	"testing"
+	"time"
+
+	natsbroker "github.com/nats-io/nats.go"
+)
+
+const recorderBufferSize = 1024
+
+// Recorder records messages, received on subject, for awaiting them in tests.
+type Recorder struct {
+	messages chan *natsbroker.Msg
+}
+
+// Subscribe connects to broker with provided URL and records messages, received on provided subject.
+// Subject can contain wildcards. Connection is closed on test cleanup.
+func Subscribe(t testing.TB, url, subject string) *Recorder {
+	t.Helper()
+
+	connection, err := natsbroker.Connect(url)
+	if err != nil {
+		t.Fatalf("failed to connect to NATS: %v", err)
+	}
+
+	t.Cleanup(connection.Close)
+
+	recorder := &Recorder{messages: make(chan *natsbroker.Msg, recorderBufferSize)}
+	if _, err = connection.ChanSubscribe(subject, recorder.messages); err != nil {
+		t.Fatalf("failed to subscribe to %q: %v", subject, err)
+	}
+
+	// Subscription is registered on server only after flush, so messages, published later, are not missed:
+	if err = connection.Flush(); err != nil {
+		t.Fatalf("failed to flush subscription to %q: %v", subject, err)
+	}
+
+	return recorder
+}
+
+// Await waits for provided number of messages and returns them. Test fails, if messages are not received
+// during timeout.
+func (r *Recorder) Await(t testing.TB, count int, timeout time.Duration) []*natsbroker.Msg {
+	t.Helper()
+
+	messages := make([]*natsbroker.Msg, 0, count)
+	timer := time.NewTimer(timeout)
+
+	defer timer.Stop()
+
+	for len(messages) < count {
+		select {
+		case message := <-r.messages:
+			messages = append(messages, message)
+		case <-timer.C:
+			t.Fatalf("received %d of %d messages during %s", len(messages), count, timeout)
+
+			return messages
+		}
+	}
+
+	return messages
+}
+
+// AwaitMessage waits for single message and returns it. Test fails, if message is not received during timeout.
+func (r *Recorder) AwaitMessage(t testing.TB, timeout time.Duration) *natsbroker.Msg {
+	t.Helper()
+
+	return r.Await(t, 1, timeout)[0]
+}
+
+// AssertNoMessages fails test, if any message is received during provided duration.
+func (r *Recorder) AssertNoMessages(t testing.TB, duration time.Duration) {
+	t.Helper()
+
+	select {
+	case message := <-r.messages:
+		t.Fatalf("unexpected message on %q: %s", message.Subject, message.Data)
+	case <-time.After(duration):
+	}
+}
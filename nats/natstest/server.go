@@ -0,0 +1,105 @@
+package natstest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+)
+
+const defaultStartTimeout = 5 * time.Second
+
+// Server is an embedded NATS server, which listens on random local port.
+type Server struct {
+	server *server.Server
+}
+
+// StartServer starts embedded NATS server with provided options. Server is shut down on test cleanup.
+func StartServer(t testing.TB, opts ...ServerOption) *Server {
+	t.Helper()
+
+	options := &serverOptions{
+		startTimeout: defaultStartTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	config := &server.Options{
+		Host:   "127.0.0.1",
+		Port:   server.RANDOM_PORT,
+		NoLog:  true,
+		NoSigs: true,
+	}
+
+	if options.jetStream {
+		config.JetStream = true
+		config.StoreDir = options.storeDir
+		if config.StoreDir == "" {
+			config.StoreDir = t.TempDir()
+		}
+	}
+
+	natsServer, err := server.NewServer(config)
+	if err != nil {
+		t.Fatalf("failed to create embedded NATS server: %v", err)
+	}
+
+	go natsServer.Start()
+
+	if !natsServer.ReadyForConnections(options.startTimeout) {
+		natsServer.Shutdown()
+		t.Fatalf("embedded NATS server is not ready for connections after %s", options.startTimeout)
+	}
+
+	t.Cleanup(func() {
+		natsServer.Shutdown()
+		natsServer.WaitForShutdown()
+	})
+
+	return &Server{server: natsServer}
+}
+
+// URL returns URL for connection to server.
+func (s *Server) URL() string {
+	return s.server.ClientURL()
+}
+
+// Shutdown stops server before test cleanup, for example, to test reconnects.
+func (s *Server) Shutdown() {
+	s.server.Shutdown()
+	s.server.WaitForShutdown()
+}
+
+// serverOptions represents options for Server configuration.
+type serverOptions struct {
+	jetStream    bool
+	storeDir     string
+	startTimeout time.Duration
+}
+
+// ServerOption represents golang functional option pattern func for Server configuration.
+type ServerOption func(options *serverOptions)
+
+// WithJetStream enables JetStream with storage in temporary directory of test.
+func WithJetStream() ServerOption {
+	return func(options *serverOptions) {
+		options.jetStream = true
+	}
+}
+
+// WithStoreDir enables JetStream with storage in provided directory.
+func WithStoreDir(dir string) ServerOption {
+	return func(options *serverOptions) {
+		options.jetStream = true
+		options.storeDir = dir
+	}
+}
+
+// WithStartTimeout sets timeout of waiting for server readiness. Default timeout is 5 seconds.
+func WithStartTimeout(timeout time.Duration) ServerOption {
+	return func(options *serverOptions) {
+		options.startTimeout = timeout
+	}
+}
@@ -0,0 +1,110 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// ObjectStore is a JetStream object store bucket for files and blobs of any size. Objects are split
+// to chunks, so they are streamed without loading whole content to memory.
+type ObjectStore struct {
+	store jetstream.ObjectStore
+}
+
+// NewObjectStore creates bucket with provided config or updates existing one and returns *ObjectStore for it.
+func NewObjectStore(
+	ctx context.Context,
+	jetStream jetstream.JetStream,
+	config jetstream.ObjectStoreConfig,
+) (*ObjectStore, error) {
+	store, err := jetStream.CreateOrUpdateObjectStore(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ObjectStore{store: store}, nil
+}
+
+// ObjectStore returns jetstream.ObjectStore for operations, not covered by store.
+func (s *ObjectStore) ObjectStore() jetstream.ObjectStore {
+	return s.store
+}
+
+// Put streams content from reader to object with name and metadata from meta.
+// Existing object with the same name is replaced.
+func (s *ObjectStore) Put(
+	ctx context.Context,
+	meta jetstream.ObjectMeta,
+	reader io.Reader,
+) (*jetstream.ObjectInfo, error) {
+	return s.store.Put(ctx, meta, reader)
+}
+
+// Get opens object with provided name for reading. Returned reader should be closed by caller.
+// Returns *NotFoundError, if object does not exist.
+func (s *ObjectStore) Get(ctx context.Context, name string) (jetstream.ObjectResult, error) {
+	result, err := s.store.Get(ctx, name)
+	if err != nil {
+		return nil, objectError(name, err)
+	}
+
+	return result, nil
+}
+
+// Download writes content of object with provided name to destination. Returns number of written bytes.
+// Digest of content is verified by reader, so corrupted object causes error.
+func (s *ObjectStore) Download(ctx context.Context, name string, destination io.Writer) (int64, error) {
+	result, err := s.Get(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		_ = result.Close()
+	}()
+
+	return io.Copy(destination, result)
+}
+
+// Info returns information and metadata of object with provided name.
+// Returns *NotFoundError, if object does not exist.
+func (s *ObjectStore) Info(ctx context.Context, name string) (*jetstream.ObjectInfo, error) {
+	info, err := s.store.GetInfo(ctx, name)
+	if err != nil {
+		return nil, objectError(name, err)
+	}
+
+	return info, nil
+}
+
+// UpdateMeta replaces metadata of object with provided name. Object is renamed, if meta contains other name.
+func (s *ObjectStore) UpdateMeta(ctx context.Context, name string, meta jetstream.ObjectMeta) error {
+	return objectError(name, s.store.UpdateMeta(ctx, name, meta))
+}
+
+// List returns information about all objects of bucket, which were not deleted.
+func (s *ObjectStore) List(ctx context.Context) ([]*jetstream.ObjectInfo, error) {
+	objects, err := s.store.List(ctx)
+	if errors.Is(err, jetstream.ErrNoObjectsFound) {
+		return []*jetstream.ObjectInfo{}, nil
+	}
+
+	return objects, err
+}
+
+// Delete deletes object with provided name. Returns *NotFoundError, if object does not exist.
+func (s *ObjectStore) Delete(ctx context.Context, name string) error {
+	return objectError(name, s.store.Delete(ctx, name))
+}
+
+// objectError converts errors of missing objects to *NotFoundError.
+func objectError(name string, err error) error {
+	if errors.Is(err, jetstream.ErrObjectNotFound) {
+		return &NotFoundError{Message: "object " + name + " not found", BaseErr: err}
+	}
+
+	return err
+}
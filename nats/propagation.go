@@ -0,0 +1,57 @@
+package nats
+
+import (
+	"context"
+
+	"github.com/DKhorkov/libs/contextlib"
+	"github.com/DKhorkov/libs/requestid"
+	natsbroker "github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// traceContextPropagator injects and extracts W3C traceparent and tracestate headers.
+var traceContextPropagator = propagation.TraceContext{}
+
+// headerCarrier adapts natsbroker.Header to propagation.TextMapCarrier. Unlike propagation.HeaderCarrier,
+// it does not canonicalize keys, because NATS headers are case-sensitive.
+type headerCarrier natsbroker.Header
+
+func (c headerCarrier) Get(key string) string {
+	return natsbroker.Header(c).Get(key)
+}
+
+func (c headerCarrier) Set(key, value string) {
+	natsbroker.Header(c).Set(key, value)
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// InjectHeaders sets request ID and W3C trace context of provided ctx to message headers.
+func InjectHeaders(ctx context.Context, header natsbroker.Header) {
+	if requestID, err := contextlib.ValueFromContext[string](ctx, requestid.Key); err == nil && requestID != "" {
+		header.Set(requestid.Key, requestID)
+	}
+
+	traceContextPropagator.Inject(ctx, headerCarrier(header))
+}
+
+// ContextFromHeaders returns ctx with request ID and remote span context from message headers,
+// which were set by InjectHeaders.
+func ContextFromHeaders(ctx context.Context, header natsbroker.Header) context.Context {
+	if header == nil {
+		return ctx
+	}
+
+	if requestID := header.Get(requestid.Key); requestID != "" {
+		ctx = contextlib.WithValue(ctx, requestid.Key, requestID)
+	}
+
+	return traceContextPropagator.Extract(ctx, headerCarrier(header))
+}
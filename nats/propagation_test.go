@@ -0,0 +1,114 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DKhorkov/libs/contextlib"
+	"github.com/DKhorkov/libs/requestid"
+	mocktracing "github.com/DKhorkov/libs/tracing/mocks"
+	natsbroker "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+)
+
+// remoteSpanContext returns ctx with sampled span context, as if it was created by tracing.Provider.
+func remoteSpanContext(t *testing.T) (context.Context, trace.SpanContext) {
+	t.Helper()
+
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	return trace.ContextWithSpanContext(context.Background(), spanContext), spanContext
+}
+
+func TestPropagation(t *testing.T) {
+	t.Parallel()
+
+	ctx, spanContext := remoteSpanContext(t)
+	ctx = contextlib.WithValue(ctx, requestid.Key, "request-id")
+
+	message := newMessage(ctx, "orders.created", []byte("data"), natsbroker.Header{"X-Custom": []string{"value"}})
+	require.Equal(t, "request-id", message.Header.Get(requestid.Key))
+	require.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", message.Header.Get("traceparent"))
+	require.Equal(t, "value", message.Header.Get("X-Custom"))
+
+	extracted := ContextFromHeaders(context.Background(), message.Header)
+
+	requestID, err := contextlib.ValueFromContext[string](extracted, requestid.Key)
+	require.NoError(t, err)
+	require.Equal(t, "request-id", requestID)
+
+	extractedSpanContext := trace.SpanContextFromContext(extracted)
+	require.Equal(t, spanContext.TraceID(), extractedSpanContext.TraceID())
+	require.Equal(t, spanContext.SpanID(), extractedSpanContext.SpanID())
+	require.True(t, extractedSpanContext.IsRemote())
+}
+
+func TestPropagationWithoutContextValues(t *testing.T) {
+	t.Parallel()
+
+	headers := natsbroker.Header{}
+	message := newMessage(context.Background(), "orders.created", nil, headers)
+	require.Empty(t, message.Header)
+
+	// Headers of caller should not be changed:
+	message.Header.Set("X-Custom", "value")
+	require.Empty(t, headers)
+
+	ctx := ContextFromHeaders(context.Background(), nil)
+	require.False(t, trace.SpanContextFromContext(ctx).IsValid())
+}
+
+func TestCommonConsumerProcessTracing(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	traceProvider := mocktracing.NewMockProvider(ctrl)
+
+	ctx, spanContext := remoteSpanContext(t)
+	ctx = contextlib.WithValue(ctx, requestid.Key, "request-id")
+	message := newMessage(ctx, "orders.created", nil, nil)
+
+	traceProvider.
+		EXPECT().
+		Span(gomock.Any(), "nats.consume orders.created", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ string, _ ...trace.SpanStartOption) (context.Context, trace.Span) {
+			require.Equal(t, spanContext.TraceID(), trace.SpanContextFromContext(ctx).TraceID())
+
+			return ctx, mocktracing.NewMockSpan()
+		}).
+		Times(1)
+
+	var (
+		handlerRequestID string
+		reportedErr      error
+	)
+
+	consumer := &CommonConsumer{
+		traceProvider: traceProvider,
+		handler: func(ctx context.Context, _ *natsbroker.Msg) error {
+			handlerRequestID, _ = contextlib.ValueFromContext[string](ctx, requestid.Key)
+
+			return errors.New("handler error")
+		},
+		errorHandler: func(_ *natsbroker.Conn, _ *natsbroker.Subscription, err error) {
+			reportedErr = err
+		},
+	}
+
+	consumer.process(context.Background(), message)
+	require.Equal(t, "request-id", handlerRequestID)
+	require.IsType(t, &MessageProcessingError{}, reportedErr)
+}
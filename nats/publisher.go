@@ -1,27 +1,86 @@
 package nats
 
-import natsbroker "github.com/nats-io/nats.go"
+import (
+	"context"
+
+	natsbroker "github.com/nats-io/nats.go"
+)
 
 // CommonPublisher is a base NATS publisher.
 type CommonPublisher struct {
-	connection *natsbroker.Conn
+	connection        *natsbroker.Conn
+	metricsEnabled    bool
+	subjectNormalizer SubjectNormalizer
 }
 
-// NewPublisher creates *CommonPublisher.
+// NewPublisher creates *CommonPublisher with provided NATS options.
 func NewPublisher(url string, opts ...natsbroker.Option) (*CommonPublisher, error) {
-	connection, err := natsbroker.Connect(url, opts...)
+	return NewPublisherWithOptions(url, WithPublisherNatsOptions(opts...))
+}
+
+// NewPublisherWithOptions creates *CommonPublisher with provided options.
+func NewPublisherWithOptions(url string, opts ...PublisherOption) (*CommonPublisher, error) {
+	options := newPublisherOptions()
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, err
+		}
+	}
+
+	connection, err := natsbroker.Connect(url, options.natsOpts...)
 	if err != nil {
 		return nil, err
 	}
 
+	if options.metricsEnabled {
+		instrumentConnection(connection)
+	}
+
 	return &CommonPublisher{
-		connection: connection,
+		connection:        connection,
+		metricsEnabled:    options.metricsEnabled,
+		subjectNormalizer: options.subjectNormalizer,
 	}, nil
 }
 
 // Publish sends message to provided topic (subject).
 func (p *CommonPublisher) Publish(topic string, data []byte) error {
-	return p.connection.Publish(topic, data)
+	err := p.connection.Publish(topic, data)
+	if p.metricsEnabled {
+		observePublish(p.subjectNormalizer, topic, len(data), err)
+	}
+
+	return err
+}
+
+// PublishContext sends message with provided headers to provided subject. Request ID and W3C trace context
+// of ctx are added to headers, so consumer can continue trace and logs of request.
+func (p *CommonPublisher) PublishContext(
+	ctx context.Context,
+	subject string,
+	data []byte,
+	headers natsbroker.Header,
+) error {
+	err := p.connection.PublishMsg(newMessage(ctx, subject, data, headers))
+	if p.metricsEnabled {
+		observePublish(p.subjectNormalizer, subject, len(data), err)
+	}
+
+	return err
+}
+
+// newMessage creates message with copy of provided headers and propagated context of ctx.
+func newMessage(ctx context.Context, subject string, data []byte, headers natsbroker.Header) *natsbroker.Msg {
+	message := natsbroker.NewMsg(subject)
+	message.Data = data
+
+	for key, values := range headers {
+		message.Header[key] = append([]string(nil), values...)
+	}
+
+	InjectHeaders(ctx, message.Header)
+
+	return message
 }
 
 // Close closes NATS connection.
@@ -0,0 +1,93 @@
+package nats
+
+import natsbroker "github.com/nats-io/nats.go"
+
+const defaultPublisherBufferSize = 1024
+
+// PublishErrorHandler handles message, which AsyncPublisher failed to publish and to spill.
+// Message is nil, if error is not related to particular message, for example, if spill file could not be read.
+type PublishErrorHandler func(message *natsbroker.Msg, err error)
+
+// SubjectNormalizer converts subject of published message to value of metrics label. It is used to bound
+// metrics cardinality, when subjects contain unbounded parts like identifiers, for example, by replacing them
+// with wildcards: "orders.42.created" => "orders.*.created".
+type SubjectNormalizer func(subject string) string
+
+// publisherOptions represents options for Publisher configuration.
+type publisherOptions struct {
+	metricsEnabled      bool
+	subjectNormalizer   SubjectNormalizer
+	natsOpts            []natsbroker.Option
+	bufferSize          int
+	spillFilePath       string
+	publishErrorHandler PublishErrorHandler
+}
+
+func newPublisherOptions() *publisherOptions {
+	return &publisherOptions{
+		bufferSize: defaultPublisherBufferSize,
+	}
+}
+
+// PublisherOption represents golang functional option pattern func for Publisher configuration.
+type PublisherOption func(options *publisherOptions) error
+
+// WithPublisherNatsOptions sets NATS option for connection with broker configuration.
+func WithPublisherNatsOptions(opts ...natsbroker.Option) PublisherOption {
+	return func(options *publisherOptions) error {
+		options.natsOpts = append(options.natsOpts, opts...)
+
+		return nil
+	}
+}
+
+// WithPublisherMetrics enables Prometheus metrics of published messages and connection state for publishers,
+// created via NewPublisherWithOptions or NewAsyncPublisher. Metrics are registered in default Prometheus registry.
+// Published messages and bytes are labeled by subject, which can be normalized via WithPublishSubjectNormalizer.
+// Connection state metrics are labeled by connection name, which can be set via
+// WithPublisherNatsOptions(natsbroker.Name(...)).
+func WithPublisherMetrics() PublisherOption {
+	return func(options *publisherOptions) error {
+		options.metricsEnabled = true
+
+		return nil
+	}
+}
+
+// WithPublishSubjectNormalizer sets SubjectNormalizer, which converts subjects of published messages
+// to values of metrics label. By default, subjects are used as is.
+func WithPublishSubjectNormalizer(normalizer SubjectNormalizer) PublisherOption {
+	return func(options *publisherOptions) error {
+		options.subjectNormalizer = normalizer
+
+		return nil
+	}
+}
+
+// WithBufferSize sets size of in-memory buffer of AsyncPublisher. Default size is 1024 messages.
+func WithBufferSize(size int) PublisherOption {
+	return func(options *publisherOptions) error {
+		options.bufferSize = size
+
+		return nil
+	}
+}
+
+// WithSpillFile sets path to file, to which AsyncPublisher writes messages, when buffer is full
+// or connection with NATS is lost. Spilled messages are published after reconnect, during Flush and Close.
+func WithSpillFile(path string) PublisherOption {
+	return func(options *publisherOptions) error {
+		options.spillFilePath = path
+
+		return nil
+	}
+}
+
+// WithPublishErrorHandler sets handler for messages, which AsyncPublisher failed to publish and to spill.
+func WithPublishErrorHandler(handler PublishErrorHandler) PublisherOption {
+	return func(options *publisherOptions) error {
+		options.publishErrorHandler = handler
+
+		return nil
+	}
+}
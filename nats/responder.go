@@ -0,0 +1,235 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	natsbroker "github.com/nats-io/nats.go"
+)
+
+var errNoReplySubject = errors.New("request has no reply subject")
+
+// RPCRequest is a request, received by Responder.
+type RPCRequest struct {
+	Message *natsbroker.Msg
+	codec   Codec
+}
+
+// Decode decodes request data to v with codec of Responder.
+func (r *RPCRequest) Decode(v any) error {
+	return r.codec.Unmarshal(r.Message.Data, v)
+}
+
+// RPCHandler processes request and returns response, which is encoded with codec of Responder.
+// Returned error is sent to requester in reply headers. *RPCError allows to set error code,
+// other errors are sent with "internal" code.
+type RPCHandler func(ctx context.Context, request *RPCRequest) (any, error)
+
+// NewRPCHandler creates RPCHandler, which decodes request to Req and calls provided handler.
+// Requests, which can not be decoded, are replied with "bad_request" error code.
+func NewRPCHandler[Req, Resp any](handler func(ctx context.Context, request Req) (Resp, error)) RPCHandler {
+	return func(ctx context.Context, request *RPCRequest) (any, error) {
+		decoded, err := decode[Req](request.codec, request.Message.Data)
+		if err != nil {
+			return nil, &RPCError{Code: RPCErrorCodeBadRequest, Message: err.Error()}
+		}
+
+		return handler(ctx, decoded)
+	}
+}
+
+// Responder replies to requests, sent to registered subjects. Requests are processed in goroutines pool
+// of CommonConsumer.
+type Responder struct {
+	connection      *natsbroker.Conn
+	codec           Codec
+	queueGroup      string
+	consumerOptions *consumerOptions
+	handlers        map[string]RPCHandler
+	subjects        []string
+	consumer        *CommonConsumer
+	mu              sync.Mutex
+}
+
+// NewResponder creates *Responder with provided options. Handlers should be registered via Handle before Run.
+func NewResponder(url string, opts ...ResponderOption) (*Responder, error) {
+	options := newResponderOptions()
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, err
+		}
+	}
+
+	consumerOpts := newConsumerOptions()
+	for _, opt := range options.consumerOpts {
+		if err := opt(consumerOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	connection, err := connect(url, consumerOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	responder := &Responder{
+		connection:      connection,
+		codec:           options.codec,
+		queueGroup:      options.queueGroup,
+		consumerOptions: consumerOpts,
+		handlers:        make(map[string]RPCHandler),
+	}
+
+	consumerOpts.handler = responder.respond
+
+	return responder, nil
+}
+
+// Handle registers handler for requests, sent to provided subject. Subject can contain wildcards.
+func (r *Responder) Handle(subject string, handler RPCHandler) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.consumer != nil {
+		return &ConsumerAlreadyRunningError{Message: "handlers can not be registered for running responder"}
+	}
+
+	if _, ok := r.handlers[subject]; !ok {
+		r.subjects = append(r.subjects, subject)
+	}
+
+	r.handlers[subject] = handler
+
+	return nil
+}
+
+// Run subscribes to subjects of registered handlers and starts goroutines for requests processing.
+func (r *Responder) Run() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.consumer != nil {
+		return &ConsumerAlreadyRunningError{}
+	}
+
+	consumer, err := newCommonConsumer(r.connection, r.subjects, r.queueGroup, r.consumerOptions)
+	if err != nil {
+		return err
+	}
+
+	if err = consumer.Run(); err != nil {
+		return err
+	}
+
+	r.consumer = consumer
+
+	return nil
+}
+
+// Stop unsubscribes from subjects, waits for already received requests to be processed and closes connection.
+func (r *Responder) Stop() error {
+	return r.Shutdown(context.Background())
+}
+
+// Shutdown gracefully stops responder like CommonConsumer.Shutdown: requests, which were already received,
+// are processed until ctx is done.
+func (r *Responder) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.consumer == nil {
+		r.connection.Close()
+
+		return nil
+	}
+
+	return r.consumer.Shutdown(ctx)
+}
+
+// respond calls handler of subscription, which received request, and publishes reply. Reply failures are
+// reported via error handler of responder instead of returning them, because request should not be processed
+// again or sent to dead letter subject only because reply was not sent.
+func (r *Responder) respond(ctx context.Context, msg *natsbroker.Msg) error {
+	if msg.Reply == "" {
+		return errNoReplySubject
+	}
+
+	if deadline, err := time.Parse(time.RFC3339Nano, msg.Header.Get(RPCDeadlineHeader)); err == nil {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+
+	response, err := r.call(ctx, msg)
+
+	if err = msg.RespondMsg(r.replyMessage(msg.Reply, response, err)); err != nil {
+		r.consumerOptions.errorHandler(
+			r.connection,
+			msg.Sub,
+			&MessageProcessingError{
+				Message: fmt.Sprintf("reply to request from subject %q was not sent", msg.Subject),
+				BaseErr: err,
+			},
+		)
+	}
+
+	return nil
+}
+
+// call calls handler for request, converting its panic to error.
+func (r *Responder) call(ctx context.Context, msg *natsbroker.Msg) (response any, err error) {
+	var handler RPCHandler
+	if msg.Sub != nil {
+		handler = r.handlers[msg.Sub.Subject]
+	}
+
+	if handler == nil {
+		return nil, &RPCError{Code: RPCErrorCodeInternal, Message: "no handler for subject " + msg.Subject}
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = &HandlerPanicError{BaseErr: fmt.Errorf("%v", recovered)}
+		}
+	}()
+
+	return handler(ctx, &RPCRequest{Message: msg, codec: r.codec})
+}
+
+// replyMessage creates reply with encoded response or with error headers, if handler failed.
+func (r *Responder) replyMessage(subject string, response any, err error) *natsbroker.Msg {
+	reply := natsbroker.NewMsg(subject)
+
+	if err == nil {
+		reply.Data, err = r.codec.Marshal(response)
+	}
+
+	if err == nil {
+		reply.Header.Set(ContentTypeHeader, r.codec.ContentType())
+
+		return reply
+	}
+
+	code, message := RPCErrorCodeInternal, err.Error()
+
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) {
+		if rpcErr.Code != "" {
+			code = rpcErr.Code
+		}
+
+		if rpcErr.Message != "" {
+			message = rpcErr.Message
+		}
+	}
+
+	reply.Data = nil
+	reply.Header.Set(RPCErrorCodeHeader, code)
+	reply.Header.Set(RPCErrorMessageHeader, headerValue(message))
+
+	return reply
+}
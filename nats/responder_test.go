@@ -0,0 +1,72 @@
+//go:build integration
+
+package nats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const rpcSubject = "test.rpc"
+
+func TestRequestReply(t *testing.T) {
+	responder, err := NewResponder(url, WithResponderQueueGroup("test-responders"))
+	require.NoError(t, err)
+
+	err = responder.Handle(
+		rpcSubject,
+		NewRPCHandler(func(_ context.Context, request rpcPayload) (rpcPayload, error) {
+			if request.Value < 0 {
+				return rpcPayload{}, &RPCError{Code: "negative", Message: "value is negative"}
+			}
+
+			return rpcPayload{Value: request.Value * 2}, nil
+		}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, responder.Run())
+
+	defer func() {
+		require.NoError(t, responder.Stop())
+	}()
+
+	client, err := NewRPCClient(url, WithRequestTimeout(time.Second))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, client.Close())
+	}()
+
+	t.Run("successful request", func(t *testing.T) {
+		response, err := Request[rpcPayload](context.Background(), client, rpcSubject, rpcPayload{Value: 21})
+		require.NoError(t, err)
+		require.Equal(t, 42, response.Value)
+	})
+
+	t.Run("error reply", func(t *testing.T) {
+		_, err := Request[rpcPayload](context.Background(), client, rpcSubject, rpcPayload{Value: -1})
+
+		var rpcErr *RPCError
+		require.ErrorAs(t, err, &rpcErr)
+		require.Equal(t, "negative", rpcErr.Code)
+		require.Equal(t, "value is negative", rpcErr.Message)
+	})
+
+	t.Run("no responders", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		_, err := Request[rpcPayload](ctx, client, "test.rpc.unknown", rpcPayload{})
+		require.Error(t, err)
+		require.False(t, errors.Is(err, context.Canceled))
+	})
+
+	t.Run("handlers can not be registered for running responder", func(t *testing.T) {
+		err := responder.Handle("test.rpc.other", nil)
+		require.IsType(t, &ConsumerAlreadyRunningError{}, err)
+	})
+}
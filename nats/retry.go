@@ -0,0 +1,57 @@
+package nats
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy configures retries of failed message processing by CommonConsumer.
+type RetryPolicy struct {
+	// MaxAttempts is a maximum number of handler calls for single message, including the first one.
+	// Values less than 2 disable retries.
+	MaxAttempts int
+
+	// InitialBackoff is a delay before second attempt. Each next delay is doubled.
+	InitialBackoff time.Duration
+
+	// MaxBackoff limits delay between attempts.
+	MaxBackoff time.Duration
+
+	// Jitter is a fraction of delay in range [0, 1], by which delay is randomly reduced
+	// not to retry messages from different consumers simultaneously.
+	Jitter float64
+}
+
+// delay returns delay before provided attempt, where attempt 2 is the first retry.
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	if attempt < 2 {
+		return 0
+	}
+
+	delay := backoffDelay(p.InitialBackoff, max(p.MaxBackoff, p.InitialBackoff), uint64(attempt-1))
+
+	jitter := min(max(p.Jitter, 0), 1)
+	if jitter > 0 {
+		delay -= time.Duration(jitter * rand.Float64() * float64(delay)) //nolint:gosec // jitter needs no crypto
+	}
+
+	return delay
+}
+
+// sleep waits for provided delay or until ctx is done. Returns false, if ctx was done earlier.
+func sleep(ctx context.Context, delay time.Duration) bool {
+	if delay <= 0 {
+		return ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
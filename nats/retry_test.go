@@ -0,0 +1,158 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	natsbroker "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     30 * time.Millisecond,
+	}
+
+	require.Equal(t, time.Duration(0), policy.delay(1))
+	require.Equal(t, 10*time.Millisecond, policy.delay(2))
+	require.Equal(t, 20*time.Millisecond, policy.delay(3))
+	require.Equal(t, 30*time.Millisecond, policy.delay(4))
+
+	policy.Jitter = 0.5
+	for range 100 {
+		delay := policy.delay(3)
+		require.GreaterOrEqual(t, delay, 10*time.Millisecond)
+		require.LessOrEqual(t, delay, 20*time.Millisecond)
+	}
+}
+
+func TestSleep(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, sleep(context.Background(), time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.False(t, sleep(ctx, time.Hour))
+	require.False(t, sleep(ctx, 0))
+}
+
+func TestCommonConsumerHandleWithRetries(t *testing.T) {
+	t.Parallel()
+
+	handlerErr := errors.New("handler error")
+
+	testCases := []struct {
+		name             string
+		maxAttempts      int
+		failures         int
+		panics           bool
+		expectedAttempts int
+		expectedErr      bool
+	}{
+		{
+			name:             "succeeds at first attempt",
+			maxAttempts:      3,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "succeeds after retries",
+			maxAttempts:      3,
+			failures:         2,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "all attempts failed",
+			maxAttempts:      3,
+			failures:         5,
+			expectedAttempts: 3,
+			expectedErr:      true,
+		},
+		{
+			name:             "retries are disabled",
+			failures:         1,
+			expectedAttempts: 1,
+			expectedErr:      true,
+		},
+		{
+			name:             "panic is recovered",
+			maxAttempts:      2,
+			failures:         5,
+			panics:           true,
+			expectedAttempts: 2,
+			expectedErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			consumer := &CommonConsumer{
+				retryPolicy: RetryPolicy{MaxAttempts: tc.maxAttempts, InitialBackoff: time.Millisecond},
+				handler: func(_ context.Context, _ *natsbroker.Msg) error {
+					calls++
+					if calls > tc.failures {
+						return nil
+					}
+
+					if tc.panics {
+						panic("boom")
+					}
+
+					return handlerErr
+				},
+			}
+
+			attempts, err := consumer.handleWithRetries(context.Background(), natsbroker.NewMsg("subject"))
+			require.Equal(t, tc.expectedAttempts, attempts)
+			require.Equal(t, tc.expectedAttempts, calls)
+
+			if !tc.expectedErr {
+				require.NoError(t, err)
+
+				return
+			}
+
+			if tc.panics {
+				var panicErr *HandlerPanicError
+				require.ErrorAs(t, err, &panicErr)
+			} else {
+				require.ErrorIs(t, err, handlerErr)
+			}
+		})
+	}
+}
+
+func TestNewDeadLetterMessage(t *testing.T) {
+	t.Parallel()
+
+	original := natsbroker.NewMsg("orders.created")
+	original.Data = []byte("payload")
+	original.Header.Add("X-Request-Id", "request")
+	original.Header.Add("X-Multi", "first")
+	original.Header.Add("X-Multi", "second")
+
+	message := newDeadLetterMessage("orders.dlq", original, errors.New("handler error"), 3)
+
+	require.Equal(t, "orders.dlq", message.Subject)
+	require.Equal(t, original.Data, message.Data)
+	require.Equal(t, "request", message.Header.Get("X-Request-Id"))
+	require.Equal(t, []string{"first", "second"}, message.Header.Values("X-Multi"))
+	require.Equal(t, "orders.created", message.Header.Get(DeadLetterOriginalSubjectHeader))
+	require.Equal(t, "handler error", message.Header.Get(DeadLetterErrorHeader))
+	require.Equal(t, "3", message.Header.Get(DeadLetterAttemptsHeader))
+
+	_, err := time.Parse(time.RFC3339Nano, message.Header.Get(DeadLetterFailedAtHeader))
+	require.NoError(t, err)
+
+	// Original message headers should not be changed:
+	require.Empty(t, original.Header.Get(DeadLetterErrorHeader))
+}
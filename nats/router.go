@@ -0,0 +1,133 @@
+package nats
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	natsbroker "github.com/nats-io/nats.go"
+)
+
+// NATS subject wildcards.
+const (
+	singleTokenWildcard = "*"
+	fullWildcard        = ">"
+	subjectSeparator    = "."
+)
+
+// routerNode is a node of subject tokens tree.
+type routerNode struct {
+	children     map[string]*routerNode
+	handler      MessageHandler
+	tailsHandler MessageHandler
+}
+
+// SubjectRouter routes messages to handlers by their subjects. Patterns can contain "*" wildcard,
+// which matches single token, and ">" wildcard, which matches one or more tail tokens.
+// If several patterns match subject, the most specific one is used: literal token is preferred over "*"
+// and "*" is preferred over ">".
+type SubjectRouter struct {
+	root *routerNode
+	mu   sync.RWMutex
+}
+
+// NewSubjectRouter creates empty *SubjectRouter.
+func NewSubjectRouter() *SubjectRouter {
+	return &SubjectRouter{root: newRouterNode()}
+}
+
+// newRouterNode creates empty *routerNode.
+func newRouterNode() *routerNode {
+	return &routerNode{children: make(map[string]*routerNode)}
+}
+
+// Handle registers handler for provided subject pattern. Handler of already registered pattern is replaced.
+func (r *SubjectRouter) Handle(pattern string, handler MessageHandler) error {
+	tokens, err := patternTokens(pattern)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	node := r.root
+	for i, token := range tokens {
+		if token == fullWildcard && i == len(tokens)-1 {
+			node.tailsHandler = handler
+
+			return nil
+		}
+
+		child, ok := node.children[token]
+		if !ok {
+			child = newRouterNode()
+			node.children[token] = child
+		}
+
+		node = child
+	}
+
+	node.handler = handler
+
+	return nil
+}
+
+// Match returns handler of the most specific pattern, which matches provided subject.
+func (r *SubjectRouter) Match(subject string) (MessageHandler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	handler := r.root.match(strings.Split(subject, subjectSeparator))
+
+	return handler, handler != nil
+}
+
+// Route passes message to handler of its subject. Returns *RouteNotFoundError, if there is no such handler.
+// Route can be used as MessageHandler of consumer.
+func (r *SubjectRouter) Route(ctx context.Context, message *natsbroker.Msg) error {
+	handler, ok := r.Match(message.Subject)
+	if !ok {
+		return &RouteNotFoundError{Message: "no handler for subject " + message.Subject}
+	}
+
+	return handler(ctx, message)
+}
+
+// match searches handler for tokens, preferring literal tokens over wildcards.
+func (n *routerNode) match(tokens []string) MessageHandler {
+	if len(tokens) == 0 {
+		return n.handler
+	}
+
+	if child, ok := n.children[tokens[0]]; ok {
+		if handler := child.match(tokens[1:]); handler != nil {
+			return handler
+		}
+	}
+
+	if child, ok := n.children[singleTokenWildcard]; ok {
+		if handler := child.match(tokens[1:]); handler != nil {
+			return handler
+		}
+	}
+
+	return n.tailsHandler
+}
+
+// patternTokens splits subject pattern to tokens and validates them.
+func patternTokens(pattern string) ([]string, error) {
+	tokens := strings.Split(pattern, subjectSeparator)
+	for i, token := range tokens {
+		switch {
+		case token == "":
+			return nil, &InvalidSubjectError{Message: "subject " + pattern + " contains empty token"}
+		case token == fullWildcard && i != len(tokens)-1:
+			return nil, &InvalidSubjectError{Message: "wildcard > should be the last token of subject " + pattern}
+		case token != fullWildcard && token != singleTokenWildcard && strings.ContainsAny(token, "*> \t"):
+			return nil, &InvalidSubjectError{Message: "subject " + pattern + " contains invalid token " + token}
+		}
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,140 @@
+package nats
+
+import (
+	"context"
+	"testing"
+
+	natsbroker "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+)
+
+// namedHandler returns MessageHandler, which stores its name to result.
+func namedHandler(name string, result *string) MessageHandler {
+	return func(context.Context, *natsbroker.Msg) error {
+		*result = name
+
+		return nil
+	}
+}
+
+func TestSubjectRouter(t *testing.T) {
+	t.Parallel()
+
+	var result string
+
+	router := NewSubjectRouter()
+	require.NoError(t, router.Handle("orders.created", namedHandler("literal", &result)))
+	require.NoError(t, router.Handle("orders.*", namedHandler("single", &result)))
+	require.NoError(t, router.Handle("orders.>", namedHandler("tail", &result)))
+	require.NoError(t, router.Handle("*.deleted.*", namedHandler("middle", &result)))
+	require.NoError(t, router.Handle(">", namedHandler("all", &result)))
+
+	testCases := []struct {
+		subject  string
+		expected string
+	}{
+		{subject: "orders.created", expected: "literal"},
+		{subject: "orders.updated", expected: "single"},
+		{subject: "orders.created.v2", expected: "tail"},
+		{subject: "users.deleted.1", expected: "middle"},
+		{subject: "orders.deleted.1", expected: "tail"},
+		{subject: "users", expected: "all"},
+		{subject: "orders", expected: "all"},
+	}
+
+	for _, tc := range testCases {
+		handler, ok := router.Match(tc.subject)
+		require.True(t, ok, tc.subject)
+		require.NoError(t, handler(context.Background(), nil))
+		require.Equal(t, tc.expected, result, tc.subject)
+	}
+}
+
+func TestSubjectRouterRoute(t *testing.T) {
+	t.Parallel()
+
+	var result string
+
+	router := NewSubjectRouter()
+	require.NoError(t, router.Handle("orders.*", namedHandler("orders", &result)))
+
+	require.NoError(t, router.Route(context.Background(), natsbroker.NewMsg("orders.created")))
+	require.Equal(t, "orders", result)
+
+	err := router.Route(context.Background(), natsbroker.NewMsg("users.created"))
+	require.IsType(t, &RouteNotFoundError{}, err)
+
+	_, ok := router.Match("orders")
+	require.False(t, ok)
+}
+
+func TestSubjectRouterInvalidPatterns(t *testing.T) {
+	t.Parallel()
+
+	router := NewSubjectRouter()
+	for _, pattern := range []string{"", "orders..created", "orders.>.created", "orders.cre*ted", "orders.a b"} {
+		err := router.Handle(pattern, namedHandler("invalid", new(string)))
+		require.IsType(t, &InvalidSubjectError{}, err, pattern)
+	}
+}
+
+func TestConsumerSubjects(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []string{"a"}, consumerSubjects("a", nil))
+	require.Equal(t, []string{"a", "b.*"}, consumerSubjects("a", []string{"b.*", "a", ""}))
+	require.Equal(t, []string{"b"}, consumerSubjects("", []string{"b"}))
+	require.Empty(t, consumerSubjects("", nil))
+	require.Equal(t, []string{"a.>"}, consumerSubjects("a.*", []string{"a.>", "a.b.c", "a.*.c"}))
+	require.Equal(t, []string{"a.*.c", "b", "a.*.d.>"}, consumerSubjects("a.b.c", []string{"a.*.c", "b", "a.*.d.>"}))
+	require.Equal(t, []string{"a.*", "a.b.>"}, consumerSubjects("a.*", []string{"a.b.>"}))
+
+	_, err := NewConsumer(natsbroker.DefaultURL, "")
+	require.IsType(t, &InvalidSubjectError{}, err)
+}
+
+func TestSubjectCovers(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		pattern  string
+		subject  string
+		expected bool
+	}{
+		{pattern: "a.>", subject: "a.b", expected: true},
+		{pattern: "a.>", subject: "a.*.c", expected: true},
+		{pattern: "a.>", subject: "a.>", expected: true},
+		{pattern: "a.>", subject: "a", expected: false},
+		{pattern: "a.*", subject: "a.b", expected: true},
+		{pattern: "a.*", subject: "a.*", expected: true},
+		{pattern: "a.*", subject: "a.>", expected: false},
+		{pattern: "a.*", subject: "a.b.c", expected: false},
+		{pattern: "a.b", subject: "a.*", expected: false},
+		{pattern: ">", subject: "a.b.c", expected: true},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, subjectCovers(tc.pattern, tc.subject), "%s covers %s", tc.pattern, tc.subject)
+	}
+}
+
+func TestRouteWithFallback(t *testing.T) {
+	t.Parallel()
+
+	var result string
+
+	options := newConsumerOptions()
+	require.NoError(t, WithHandler(namedHandler("fallback", &result))(options))
+	require.NoError(t, WithSubjectHandler("orders.*", namedHandler("orders", &result))(options))
+	require.Equal(t, []string{"orders.*"}, options.subjects)
+
+	handler := routeWithFallback(options.router, options.handler)
+
+	require.NoError(t, handler(context.Background(), natsbroker.NewMsg("orders.created")))
+	require.Equal(t, "orders", result)
+
+	require.NoError(t, handler(context.Background(), natsbroker.NewMsg("users.created")))
+	require.Equal(t, "fallback", result)
+
+	require.Error(t, WithSubjectHandler("orders.>.created", namedHandler("invalid", &result))(options))
+}
@@ -0,0 +1,153 @@
+package nats
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	natsbroker "github.com/nats-io/nats.go"
+)
+
+// Headers of RPC requests and replies.
+const (
+	RPCErrorCodeHeader    = "Nats-Rpc-Error-Code"
+	RPCErrorMessageHeader = "Nats-Rpc-Error-Message"
+	RPCDeadlineHeader     = "Nats-Rpc-Deadline"
+)
+
+// Codes of errors, which are set by Responder itself.
+const (
+	RPCErrorCodeInternal   = "internal"
+	RPCErrorCodeBadRequest = "bad_request"
+)
+
+// RPCClient sends requests to NATS subjects and waits for replies of Responder.
+type RPCClient struct {
+	connection *natsbroker.Conn
+	codec      Codec
+	timeout    time.Duration
+}
+
+// NewRPCClient creates *RPCClient with provided options.
+func NewRPCClient(url string, opts ...RPCClientOption) (*RPCClient, error) {
+	options := newRPCClientOptions()
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, err
+		}
+	}
+
+	connection, err := natsbroker.Connect(url, options.natsOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RPCClient{
+		connection: connection,
+		codec:      options.codec,
+		timeout:    options.timeout,
+	}, nil
+}
+
+// RequestMsg encodes request, sends it to provided subject and waits for reply until ctx is done.
+// If ctx has no deadline, request timeout of client is used. Error reply is returned as *RPCError.
+func (c *RPCClient) RequestMsg(ctx context.Context, subject string, request any) (*natsbroker.Msg, error) {
+	data, err := c.codec.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := ctx.Deadline(); !ok && c.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	message := natsbroker.NewMsg(subject)
+	message.Data = data
+	message.Header.Set(ContentTypeHeader, c.codec.ContentType())
+	InjectHeaders(ctx, message.Header)
+
+	if deadline, ok := ctx.Deadline(); ok {
+		message.Header.Set(RPCDeadlineHeader, deadline.UTC().Format(time.RFC3339Nano))
+	}
+
+	reply, err := c.connection.RequestMsgWithContext(ctx, message)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = replyError(reply); err != nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+// Close closes NATS connection.
+func (c *RPCClient) Close() error {
+	c.connection.Close()
+
+	return nil
+}
+
+// Request sends request to provided subject via client and decodes reply to Resp.
+func Request[Resp any](ctx context.Context, client *RPCClient, subject string, request any) (Resp, error) {
+	reply, err := client.RequestMsg(ctx, subject, request)
+	if err != nil {
+		var zero Resp
+
+		return zero, err
+	}
+
+	return decode[Resp](client.codec, reply.Data)
+}
+
+// decode decodes data to new value of T. If T is a pointer, value, it points to, is allocated,
+// so protobuf messages can be used as T.
+func decode[T any](codec Codec, data []byte) (T, error) {
+	value, err := decodeType(codec, data, reflect.TypeFor[T]())
+	if err != nil {
+		var zero T
+
+		return zero, err
+	}
+
+	// Value is created from type of T, so assertion fails only for nil value of interface type:
+	typed, _ := value.(T)
+
+	return typed, nil
+}
+
+// decodeType decodes data to new value of provided type.
+func decodeType(codec Codec, data []byte, valueType reflect.Type) (any, error) {
+	if valueType.Kind() == reflect.Pointer {
+		value := reflect.New(valueType.Elem())
+		if err := codec.Unmarshal(data, value.Interface()); err != nil {
+			return nil, err
+		}
+
+		return value.Interface(), nil
+	}
+
+	value := reflect.New(valueType)
+	if err := codec.Unmarshal(data, value.Interface()); err != nil {
+		return nil, err
+	}
+
+	return value.Elem().Interface(), nil
+}
+
+// replyError returns *RPCError, if reply contains error headers.
+func replyError(reply *natsbroker.Msg) error {
+	code := reply.Header.Get(RPCErrorCodeHeader)
+	if code == "" {
+		return nil
+	}
+
+	return &RPCError{
+		Code:    code,
+		Message: reply.Header.Get(RPCErrorMessageHeader),
+	}
+}
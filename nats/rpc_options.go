@@ -0,0 +1,100 @@
+package nats
+
+import (
+	"time"
+
+	natsbroker "github.com/nats-io/nats.go"
+)
+
+const defaultRequestTimeout = 5 * time.Second
+
+// newRPCClientOptions creates *rpcClientOptions with default values.
+func newRPCClientOptions() *rpcClientOptions {
+	return &rpcClientOptions{
+		codec:   JSONCodec{},
+		timeout: defaultRequestTimeout,
+	}
+}
+
+// rpcClientOptions represents options for RPCClient configuration.
+type rpcClientOptions struct {
+	codec    Codec
+	timeout  time.Duration
+	natsOpts []natsbroker.Option
+}
+
+// RPCClientOption represents golang functional option pattern func for RPCClient configuration.
+type RPCClientOption func(options *rpcClientOptions) error
+
+// WithClientCodec sets codec for requests and replies. JSONCodec is used by default.
+func WithClientCodec(codec Codec) RPCClientOption {
+	return func(options *rpcClientOptions) error {
+		options.codec = codec
+
+		return nil
+	}
+}
+
+// WithRequestTimeout sets timeout for requests, which context has no deadline.
+func WithRequestTimeout(timeout time.Duration) RPCClientOption {
+	return func(options *rpcClientOptions) error {
+		options.timeout = timeout
+
+		return nil
+	}
+}
+
+// WithClientNatsOptions sets NATS option for connection with broker configuration.
+func WithClientNatsOptions(opts ...natsbroker.Option) RPCClientOption {
+	return func(options *rpcClientOptions) error {
+		options.natsOpts = append(options.natsOpts, opts...)
+
+		return nil
+	}
+}
+
+// newResponderOptions creates *responderOptions with default values.
+func newResponderOptions() *responderOptions {
+	return &responderOptions{
+		codec: JSONCodec{},
+	}
+}
+
+// responderOptions represents options for Responder configuration.
+type responderOptions struct {
+	codec        Codec
+	queueGroup   string
+	consumerOpts []ConsumerOption
+}
+
+// ResponderOption represents golang functional option pattern func for Responder configuration.
+type ResponderOption func(options *responderOptions) error
+
+// WithResponderCodec sets codec for requests and replies. JSONCodec is used by default.
+func WithResponderCodec(codec Codec) ResponderOption {
+	return func(options *responderOptions) error {
+		options.codec = codec
+
+		return nil
+	}
+}
+
+// WithResponderQueueGroup sets queue group for handlers subscriptions, so requests are load balanced
+// between responders of the same group.
+func WithResponderQueueGroup(queue string) ResponderOption {
+	return func(options *responderOptions) error {
+		options.queueGroup = queue
+
+		return nil
+	}
+}
+
+// WithResponderConsumerOptions sets options of consumer, which processes requests. Options allow to configure
+// goroutines pool, error handlers and NATS connection.
+func WithResponderConsumerOptions(opts ...ConsumerOption) ResponderOption {
+	return func(options *responderOptions) error {
+		options.consumerOpts = append(options.consumerOpts, opts...)
+
+		return nil
+	}
+}
@@ -0,0 +1,196 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	natsbroker "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type rpcPayload struct {
+	Value int `json:"value"`
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("value type", func(t *testing.T) {
+		t.Parallel()
+
+		decoded, err := decode[rpcPayload](JSONCodec{}, []byte(`{"value":1}`))
+		require.NoError(t, err)
+		require.Equal(t, rpcPayload{Value: 1}, decoded)
+	})
+
+	t.Run("pointer type", func(t *testing.T) {
+		t.Parallel()
+
+		decoded, err := decode[*rpcPayload](JSONCodec{}, []byte(`{"value":2}`))
+		require.NoError(t, err)
+		require.Equal(t, &rpcPayload{Value: 2}, decoded)
+	})
+
+	t.Run("protobuf message", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := ProtobufCodec{}.Marshal(wrapperspb.Int64(3))
+		require.NoError(t, err)
+
+		decoded, err := decode[*wrapperspb.Int64Value](ProtobufCodec{}, data)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), decoded.GetValue())
+	})
+
+	t.Run("invalid data", func(t *testing.T) {
+		t.Parallel()
+
+		decoded, err := decode[*rpcPayload](JSONCodec{}, []byte(`{`))
+		require.Error(t, err)
+		require.Nil(t, decoded)
+	})
+}
+
+func TestResponderReplyMessage(t *testing.T) {
+	t.Parallel()
+
+	responder := &Responder{codec: JSONCodec{}}
+
+	testCases := []struct {
+		name            string
+		response        any
+		err             error
+		expectedData    string
+		expectedCode    string
+		expectedMessage string
+	}{
+		{
+			name:         "successful response",
+			response:     rpcPayload{Value: 1},
+			expectedData: `{"value":1}`,
+		},
+		{
+			name:            "plain error",
+			err:             errors.New("something\nwent wrong"),
+			expectedCode:    RPCErrorCodeInternal,
+			expectedMessage: "something went wrong",
+		},
+		{
+			name:            "rpc error",
+			err:             &RPCError{Code: "not_found", Message: "user not found"},
+			expectedCode:    "not_found",
+			expectedMessage: "user not found",
+		},
+		{
+			name:            "wrapped rpc error without code",
+			err:             errors.Join(errors.New("wrapper"), &RPCError{Message: "failed"}),
+			expectedCode:    RPCErrorCodeInternal,
+			expectedMessage: "failed",
+		},
+		{
+			name:            "response can not be encoded",
+			response:        make(chan int),
+			expectedCode:    RPCErrorCodeInternal,
+			expectedMessage: "json: unsupported type: chan int",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			reply := responder.replyMessage("reply", tc.response, tc.err)
+			require.Equal(t, "reply", reply.Subject)
+			require.Equal(t, tc.expectedCode, reply.Header.Get(RPCErrorCodeHeader))
+			require.Equal(t, tc.expectedMessage, reply.Header.Get(RPCErrorMessageHeader))
+
+			if tc.expectedCode != "" {
+				require.Empty(t, reply.Data)
+
+				var rpcErr *RPCError
+				require.ErrorAs(t, replyError(reply), &rpcErr)
+				require.Equal(t, tc.expectedCode, rpcErr.Code)
+				require.Equal(t, tc.expectedMessage, rpcErr.Message)
+
+				return
+			}
+
+			require.JSONEq(t, tc.expectedData, string(reply.Data))
+			require.Equal(t, ContentTypeJSON, reply.Header.Get(ContentTypeHeader))
+			require.NoError(t, replyError(reply))
+		})
+	}
+}
+
+func TestResponderCall(t *testing.T) {
+	t.Parallel()
+
+	responder := &Responder{
+		codec: JSONCodec{},
+		handlers: map[string]RPCHandler{
+			"sum": NewRPCHandler(func(_ context.Context, request []int) (rpcPayload, error) {
+				var sum int
+				for _, value := range request {
+					sum += value
+				}
+
+				return rpcPayload{Value: sum}, nil
+			}),
+			"panic": func(context.Context, *RPCRequest) (any, error) {
+				panic("boom")
+			},
+		},
+	}
+
+	message := func(subject string, data string) *natsbroker.Msg {
+		msg := natsbroker.NewMsg(subject)
+		msg.Data = []byte(data)
+		msg.Sub = &natsbroker.Subscription{Subject: subject}
+
+		return msg
+	}
+
+	response, err := responder.call(context.Background(), message("sum", `[1,2,3]`))
+	require.NoError(t, err)
+	require.Equal(t, rpcPayload{Value: 6}, response)
+
+	var rpcErr *RPCError
+
+	_, err = responder.call(context.Background(), message("sum", `{`))
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, RPCErrorCodeBadRequest, rpcErr.Code)
+
+	_, err = responder.call(context.Background(), message("unknown", `{}`))
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, RPCErrorCodeInternal, rpcErr.Code)
+
+	var panicErr *HandlerPanicError
+
+	_, err = responder.call(context.Background(), message("panic", `{}`))
+	require.ErrorAs(t, err, &panicErr)
+}
+
+func TestResponderRespondReplyError(t *testing.T) {
+	t.Parallel()
+
+	var reported error
+
+	options := newConsumerOptions()
+	options.errorHandler = func(_ *natsbroker.Conn, _ *natsbroker.Subscription, err error) {
+		reported = err
+	}
+
+	responder := &Responder{codec: JSONCodec{}, consumerOptions: options}
+
+	// Message is not bound to subscription, so reply can not be sent:
+	msg := natsbroker.NewMsg("unbound")
+	msg.Reply = "reply"
+
+	require.NoError(t, responder.respond(context.Background(), msg))
+
+	var processingErr *MessageProcessingError
+	require.ErrorAs(t, reported, &processingErr)
+	require.ErrorIs(t, reported, natsbroker.ErrMsgNotBound)
+}
@@ -0,0 +1,86 @@
+package nats
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	natsbroker "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitFor(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, waitFor(context.Background(), func() bool { return true }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	require.False(t, waitFor(ctx, func() bool { return false }))
+}
+
+func TestCommonConsumerWaitForWorkers(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var (
+		mu        sync.Mutex
+		processed int
+		canceled  int
+	)
+
+	started := make(chan struct{}, 2)
+	consumer := &CommonConsumer{
+		ctx:                ctx,
+		cancel:             cancel,
+		messageChannel:     make(chan *natsbroker.Msg, 10),
+		goroutinesPoolSize: 2,
+		retryPolicy:        RetryPolicy{MaxAttempts: 1},
+		errorHandler:       func(*natsbroker.Conn, *natsbroker.Subscription, error) {},
+		wg:                 new(sync.WaitGroup),
+		handler: func(ctx context.Context, msg *natsbroker.Msg) error {
+			if string(msg.Data) == "slow" {
+				started <- struct{}{}
+				<-ctx.Done()
+
+				mu.Lock()
+				canceled++
+				mu.Unlock()
+
+				return ctx.Err()
+			}
+
+			mu.Lock()
+			processed++
+			mu.Unlock()
+
+			return nil
+		},
+	}
+
+	require.NoError(t, consumer.Run())
+	require.IsType(t, &ConsumerAlreadyRunningError{}, consumer.Run())
+
+	for _, data := range []string{"fast", "slow", "slow", "fast", "fast"} {
+		msg := natsbroker.NewMsg("subject")
+		msg.Data = []byte(data)
+		consumer.messageChannel <- msg
+	}
+
+	<-started
+	<-started
+	close(consumer.messageChannel)
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer shutdownCancel()
+
+	// Both goroutines are blocked by slow messages, so the last two fast messages are still in channel:
+	require.Equal(t, int64(4), consumer.waitForWorkers(shutdownCtx))
+
+	consumer.wg.Wait()
+	require.Equal(t, 1, processed)
+	require.Equal(t, 2, canceled)
+}
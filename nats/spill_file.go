@@ -0,0 +1,206 @@
+package nats
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sync"
+
+	natsbroker "github.com/nats-io/nats.go"
+)
+
+const (
+	spillFilePermissions = 0o600
+	spillFileTempSuffix  = ".tmp"
+)
+
+// spillRecord is a message, stored in spill file as single JSON line.
+type spillRecord struct {
+	Subject string            `json:"subject"`
+	Reply   string            `json:"reply,omitempty"`
+	Header  natsbroker.Header `json:"header,omitempty"`
+	Data    []byte            `json:"data"`
+}
+
+// spillFile is an append-only file with messages, which could not be published.
+type spillFile struct {
+	path    string
+	file    *os.File
+	records int
+	mu      sync.Mutex
+}
+
+// openSpillFile opens or creates spill file. Messages, spilled before restart, are kept.
+func openSpillFile(path string) (*spillFile, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, spillFilePermissions)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := readLines(file)
+	if err != nil {
+		_ = file.Close()
+
+		return nil, err
+	}
+
+	return &spillFile{path: path, file: file, records: len(lines)}, nil
+}
+
+// write appends message to the end of file.
+func (f *spillFile) write(message *natsbroker.Msg) error {
+	line, err := json.Marshal(spillRecord{
+		Subject: message.Subject,
+		Reply:   message.Reply,
+		Header:  message.Header,
+		Data:    message.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, err = f.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	f.records++
+
+	return nil
+}
+
+// replay publishes spilled messages in order of spilling, until publish fails. Published and unreadable
+// messages are removed from file, other messages are kept for next replay.
+func (f *spillFile) replay(publish func(message *natsbroker.Msg) error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.records == 0 {
+		return nil
+	}
+
+	lines, err := readLines(f.file)
+	if err != nil {
+		return err
+	}
+
+	var decodeErrs []error
+
+	published := 0
+
+	for _, line := range lines {
+		var record spillRecord
+		if err = json.Unmarshal(line, &record); err != nil {
+			decodeErrs = append(decodeErrs, err)
+			published++
+
+			continue
+		}
+
+		message := natsbroker.NewMsg(record.Subject)
+		message.Reply = record.Reply
+		message.Data = record.Data
+
+		if record.Header != nil {
+			message.Header = record.Header
+		}
+
+		if publish(message) != nil {
+			break
+		}
+
+		published++
+	}
+
+	if err = f.rewrite(lines[published:]); err != nil {
+		return err
+	}
+
+	return errors.Join(decodeErrs...)
+}
+
+// rewrite replaces content of file with provided lines. Lines are written to temporary file, which replaces
+// spill file only after being synced to disk, so spilled messages are not lost, if rewrite fails or process crashes.
+func (f *spillFile) rewrite(lines [][]byte) error {
+	tempPath := f.path + spillFileTempSuffix
+
+	file, err := os.OpenFile(tempPath, os.O_CREATE|os.O_RDWR|os.O_TRUNC|os.O_APPEND, spillFilePermissions)
+	if err != nil {
+		return err
+	}
+
+	if err = writeLines(file, lines); err == nil {
+		err = os.Rename(tempPath, f.path)
+	}
+
+	if err != nil {
+		return errors.Join(err, file.Close(), os.Remove(tempPath))
+	}
+
+	// Descriptor of temporary file refers to spill file after rename, so it replaces descriptor of old file:
+	oldFile := f.file
+	f.file = file
+	f.records = len(lines)
+
+	return oldFile.Close()
+}
+
+// writeLines writes lines to file and syncs its content to disk.
+func writeLines(file *os.File, lines [][]byte) error {
+	for _, line := range lines {
+		if _, err := file.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+
+	return file.Sync()
+}
+
+// len returns number of spilled messages.
+func (f *spillFile) len() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.records
+}
+
+// close syncs content of file to disk and closes it.
+func (f *spillFile) close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return errors.Join(f.file.Sync(), f.file.Close())
+}
+
+// readLines reads all non-empty lines of file from its beginning.
+func readLines(file *os.File) ([][]byte, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	var lines [][]byte
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 && line[len(line)-1] == '\n' {
+			line = line[:len(line)-1]
+		}
+
+		if len(line) > 0 {
+			lines = append(lines, line)
+		}
+
+		if errors.Is(err, io.EOF) {
+			return lines, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package nats
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	natsbroker "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpillFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "spill.jsonl")
+
+	spill, err := openSpillFile(path)
+	require.NoError(t, err)
+
+	for _, data := range []string{"first", "second", "third"} {
+		message := natsbroker.NewMsg("test.spill")
+		message.Header.Set("Key", data)
+		message.Data = []byte(data)
+		require.NoError(t, spill.write(message))
+	}
+
+	require.Equal(t, 3, spill.len())
+	require.NoError(t, spill.close())
+
+	// Spilled messages are kept after reopening:
+	spill, err = openSpillFile(path)
+	require.NoError(t, err)
+	require.Equal(t, 3, spill.len())
+
+	var published []string
+
+	publishErr := errors.New("connection lost")
+	err = spill.replay(func(message *natsbroker.Msg) error {
+		if len(published) == 2 {
+			return publishErr
+		}
+
+		require.Equal(t, "test.spill", message.Subject)
+		require.Equal(t, string(message.Data), message.Header.Get("Key"))
+		published = append(published, string(message.Data))
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "second"}, published)
+	require.Equal(t, 1, spill.len())
+
+	err = spill.replay(func(message *natsbroker.Msg) error {
+		published = append(published, string(message.Data))
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "second", "third"}, published)
+	require.Zero(t, spill.len())
+	require.NoError(t, spill.close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Empty(t, content)
+}
+
+func TestSpillFileFailedRewrite(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "spill.jsonl")
+
+	spill, err := openSpillFile(path)
+	require.NoError(t, err)
+
+	for _, data := range []string{"first", "second"} {
+		message := natsbroker.NewMsg("test.spill")
+		message.Data = []byte(data)
+		require.NoError(t, spill.write(message))
+	}
+
+	// Temporary file can not be created, while directory occupies its path:
+	require.NoError(t, os.Mkdir(path+spillFileTempSuffix, 0o700))
+
+	publish := func(*natsbroker.Msg) error { return nil }
+	require.Error(t, spill.replay(publish))
+	require.Equal(t, 2, spill.len())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, 2, strings.Count(string(content), "\n"))
+
+	require.NoError(t, os.Remove(path+spillFileTempSuffix))
+	require.NoError(t, spill.replay(publish))
+	require.Zero(t, spill.len())
+
+	// Messages, spilled after rewrite, are written to spill file:
+	require.NoError(t, spill.write(natsbroker.NewMsg("test.spill")))
+	require.NoError(t, spill.close())
+
+	spill, err = openSpillFile(path)
+	require.NoError(t, err)
+	require.Equal(t, 1, spill.len())
+	require.NoError(t, spill.close())
+}
+
+func TestSpillFileCorruptedRecord(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "spill.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("not json\n{\"subject\":\"test\",\"data\":\"ZGF0YQ==\"}\n"), 0o600))
+
+	spill, err := openSpillFile(path)
+	require.NoError(t, err)
+	require.Equal(t, 2, spill.len())
+
+	var published []string
+
+	err = spill.replay(func(message *natsbroker.Msg) error {
+		published = append(published, string(message.Data))
+
+		return nil
+	})
+	require.Error(t, err)
+	require.Equal(t, []string{"data"}, published)
+	require.Zero(t, spill.len())
+	require.NoError(t, spill.close())
+}
+
+func TestAsyncPublisherEnqueue(t *testing.T) {
+	t.Parallel()
+
+	newPublisher := func() *AsyncPublisher {
+		return &AsyncPublisher{
+			options: newPublisherOptions(),
+			queue:   make(chan *natsbroker.Msg, 1),
+		}
+	}
+
+	t.Run("full buffer rejects message", func(t *testing.T) {
+		t.Parallel()
+
+		publisher := newPublisher()
+		require.NoError(t, publisher.Publish("test", []byte("first")))
+		require.IsType(t, &BufferFullError{}, publisher.Publish("test", []byte("second")))
+		require.Equal(t, 1, publisher.unflushed())
+	})
+
+	t.Run("full buffer spills message", func(t *testing.T) {
+		t.Parallel()
+
+		spill, err := openSpillFile(filepath.Join(t.TempDir(), "spill.jsonl"))
+		require.NoError(t, err)
+
+		publisher := newPublisher()
+		publisher.spill = spill
+		require.NoError(t, publisher.Publish("test", []byte("first")))
+		require.NoError(t, publisher.Publish("test", []byte("second")))
+		require.Equal(t, 2, publisher.unflushed())
+		require.Equal(t, 1, spill.len())
+		require.NoError(t, spill.close())
+	})
+
+	t.Run("closed publisher rejects message", func(t *testing.T) {
+		t.Parallel()
+
+		publisher := newPublisher()
+		publisher.closed = true
+		require.IsType(t, &PublisherClosedError{}, publisher.Publish("test", []byte("first")))
+	})
+}
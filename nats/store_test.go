@@ -0,0 +1,132 @@
+//go:build integration
+
+package nats
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyValueStore(t *testing.T) {
+	ctx := context.Background()
+
+	publisher, err := NewJetStreamPublisher(url)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, publisher.Close())
+	}()
+
+	store, err := NewKeyValueStore(ctx, publisher.JetStream(), jetstream.KeyValueConfig{Bucket: "test-flags", History: 5})
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, publisher.JetStream().DeleteKeyValue(ctx, "test-flags"))
+	}()
+
+	watcher, err := store.Watch(ctx, "flags.>", jetstream.UpdatesOnly())
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, watcher.Stop())
+	}()
+
+	revision, err := store.Create(ctx, "flags.new-ui", []byte("off"))
+	require.NoError(t, err)
+
+	_, err = store.Create(ctx, "flags.new-ui", []byte("off"))
+	require.IsType(t, &KeyExistsError{}, err)
+
+	newRevision, err := store.Update(ctx, "flags.new-ui", []byte("on"), revision)
+	require.NoError(t, err)
+
+	_, err = store.Update(ctx, "flags.new-ui", []byte("off"), revision)
+	require.IsType(t, &RevisionMismatchError{}, err)
+
+	entry, err := store.Get(ctx, "flags.new-ui")
+	require.NoError(t, err)
+	require.Equal(t, []byte("on"), entry.Value)
+	require.Equal(t, newRevision, entry.Revision)
+
+	history, err := store.History(ctx, "flags.new-ui")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+
+	require.NoError(t, store.Delete(ctx, "flags.new-ui"))
+
+	_, err = store.Get(ctx, "flags.new-ui")
+	require.IsType(t, &NotFoundError{}, err)
+
+	operations := make([]KeyValueOperation, 0, 3)
+	for range 3 {
+		operations = append(operations, (<-watcher.Updates()).Operation)
+	}
+
+	require.Equal(
+		t,
+		[]KeyValueOperation{KeyValueOperationPut, KeyValueOperationPut, KeyValueOperationDelete},
+		operations,
+	)
+}
+
+func TestObjectStore(t *testing.T) {
+	ctx := context.Background()
+
+	publisher, err := NewJetStreamPublisher(url)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, publisher.Close())
+	}()
+
+	store, err := NewObjectStore(ctx, publisher.JetStream(), jetstream.ObjectStoreConfig{Bucket: "test-files"})
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, publisher.JetStream().DeleteObjectStore(ctx, "test-files"))
+	}()
+
+	content := strings.Repeat("content", 100000)
+
+	info, err := store.Put(
+		ctx,
+		jetstream.ObjectMeta{Name: "file.txt", Metadata: map[string]string{"owner": "test"}},
+		strings.NewReader(content),
+	)
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(content)), info.Size)
+
+	var buffer bytes.Buffer
+
+	written, err := store.Download(ctx, "file.txt", &buffer)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(content)), written)
+	require.Equal(t, content, buffer.String())
+
+	require.NoError(
+		t,
+		store.UpdateMeta(
+			ctx,
+			"file.txt",
+			jetstream.ObjectMeta{Name: "file.txt", Metadata: map[string]string{"owner": "other"}},
+		),
+	)
+
+	info, err = store.Info(ctx, "file.txt")
+	require.NoError(t, err)
+	require.Equal(t, "other", info.Metadata["owner"])
+
+	objects, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+
+	require.NoError(t, store.Delete(ctx, "file.txt"))
+
+	_, err = store.Get(ctx, "file.txt")
+	require.IsType(t, &NotFoundError{}, err)
+}